@@ -27,6 +27,8 @@ type (
 //
 // It accepts the path in which to scan for annotations within Go files.
 func (o *OAS) MapAnnotationsInPath(scanIn string, conf ...configAnnotation) error {
+	o.Invalidate()
+
 	filesInPath, err := scanForChangesInPath(scanIn, getWDFn(conf), walkFilepath)
 	if err != nil {
 		return fmt.Errorf(" :%w", err)
@@ -0,0 +1,37 @@
+package docs
+
+import "gopkg.in/yaml.v3"
+
+// addDescriptionComments sets descriptions of top-level tags and servers as head comments of their entries.
+func addDescriptionComments(root *yaml.Node) {
+	for _, key := range []string{keyTags, keyServers} {
+		seq := mappingValue(root, key)
+		if seq == nil || seq.Kind != yaml.SequenceNode {
+			continue
+		}
+
+		for _, item := range seq.Content {
+			desc := mappingValue(item, keyDescription)
+			if desc == nil || isStrEmpty(desc.Value) {
+				continue
+			}
+
+			item.HeadComment = desc.Value
+		}
+	}
+}
+
+// mappingValue returns the value node for the given key of a mapping node, or nil if there is none.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
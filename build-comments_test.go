@@ -0,0 +1,35 @@
+package docs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnitDescriptionsAsComments(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Tags.SetTag("user", "Operations about the User", ExternalDocs{})
+	o.Servers = Servers{Server{URL: "https://petstore.swagger.io/v2", Description: "Production"}}
+
+	yml, err := marshalToYAML(&o, ConfigBuilder{DescriptionsAsComments: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := string(yml)
+	for _, want := range []string{"# Operations about the User\n", "# Production\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+
+	yml, err = marshalToYAML(&o)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Contains(string(yml), "#") {
+		t.Errorf("expected no comments by default, got:\n%s", yml)
+	}
+}
@@ -1,26 +1,56 @@
 package docs
 
 const (
-	keyTags             = "tags"
-	keySummary          = "summary"
-	keyOperationID      = "operationId"
-	keySecurity         = "security"
-	keyRequestBody      = "requestBody"
-	keyResponses        = "responses"
-	keyDescription      = "description"
-	keyContent          = "content"
-	keyRef              = "$ref"
-	keySchemas          = "schemas"
-	keySecuritySchemes  = "securitySchemes"
-	keyName             = "name"
-	keyType             = "type"
-	keyProperties       = "properties"
-	keyIn               = "in"
-	keyXML              = "xml"
-	keyFormat           = "format"
-	keyDefault          = "default"
-	keyEnum             = "enum"
-	keyFlows            = "flows"
-	keyAuthorizationURL = "authorizationUrl"
-	keyScopes           = "scopes"
+	keyTags              = "tags"
+	keySummary           = "summary"
+	keyAllowEmptyValue   = "allowEmptyValue"
+	keyAllowReserved     = "allowReserved"
+	keyEncoding          = "encoding"
+	keyContentType       = "contentType"
+	keyHeaders           = "headers"
+	keyStyle             = "style"
+	keyExplode           = "explode"
+	keyPathItems         = "pathItems"
+	keyOpenIDConnectURL  = "openIdConnectUrl"
+	keyServers           = "servers"
+	keyOperationID       = "operationId"
+	keySecurity          = "security"
+	keyRequestBody       = "requestBody"
+	keyResponses         = "responses"
+	keyDescription       = "description"
+	keyContent           = "content"
+	keyRef               = "$ref"
+	keySchemas           = "schemas"
+	keyComponents        = "components"
+	keySecuritySchemes   = "securitySchemes"
+	keyName              = "name"
+	keyType              = "type"
+	keyProperties        = "properties"
+	keyIn                = "in"
+	keyXML               = "xml"
+	keyFormat            = "format"
+	keyDefault           = "default"
+	keyEnum              = "enum"
+	keyFlows             = "flows"
+	keyAuthorizationURL  = "authorizationUrl"
+	keyScopes            = "scopes"
+	keyUniqueItems       = "uniqueItems"
+	keyParameters        = "parameters"
+	keyRequired          = "required"
+	keySchema            = "schema"
+	keyDeprecated        = "deprecated"
+	keyConst             = "const"
+	keyContentEncoding   = "contentEncoding"
+	keyContentMediaType  = "contentMediaType"
+	keyOneOf             = "oneOf"
+	keyAnyOf             = "anyOf"
+	keyAllOf             = "allOf"
+	keyDiscriminator     = "discriminator"
+	keyExamples          = "examples"
+	keyPatternProperties = "patternProperties"
+	keyItems             = "items"
+	keyReadOnly          = "readOnly"
+	keyWriteOnly         = "writeOnly"
+	keyExample           = "example"
+	keyNullable          = "nullable"
 )
@@ -2,28 +2,211 @@ package docs
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"io"
 	"os"
+	"regexp"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
-const defaultDocsOutPath = "./internal/dist/openapi.yaml"
+const (
+	defaultDocsOutPath       = "./internal/dist/openapi.yaml"
+	defaultJSONSchemaDialect = "https://spec.openapis.org/oas/3.1/dialect/base"
+	defaultMediaType         = "application/json"
+	stdoutPath               = "-"
+	flowEnumMaxValues        = 5 // Enums with fewer values are emitted in flow style.
+	typeNull                 = "null"
+)
 
 // ConfigBuilder represents a config structure which will be used for the YAML Builder (BuildDocs fn).
 //
 // This structure was introduced to enable possible extensions to the OAS.BuildDocs()
 // without introducing breaking API changes.
 type ConfigBuilder struct {
+	// CustomPath sets the output file path, "-" writes the docs to the standard output instead,
+	// which can not be combined with Split.
 	CustomPath string
+
+	// DescriptionsAsComments additionally renders tag and server descriptions as YAML comments above the object.
+	DescriptionsAsComments bool
+
+	// ExcludeTags omits all operations carrying at least one of the listed tags from the output.
+	ExcludeTags []string
+	// ExcludeWhere omits all operations for which it returns true from the output.
+	ExcludeWhere func(path Path) bool
+	// PruneComponents omits component schemas and security schemes which are not referenced by any operation.
+	PruneComponents bool
+	// WarnUnusedSecuritySchemes logs a warning for each security scheme not required by any operation.
+	WarnUnusedSecuritySchemes bool
+	// Split writes path items to separate paths/{tag}.yaml files, next to the root output file referencing them.
+	Split bool
+	// TrailingSlash normalizes trailing slashes of routes, which are left untouched by default.
+	TrailingSlash TrailingSlashMode
+	// OperationIDCase transforms casing of operationIds, which are left as they are by default.
+	OperationIDCase OperationIDCase
+	// OperationIDPattern fails the validation for operationIds not matching it, after applying OperationIDCase.
+	// No pattern is enforced by default.
+	OperationIDPattern *regexp.Regexp
+	// AutoRequirePathParams marks path parameters as required in the built docs, logging a warning for each of them,
+	// instead of failing the validation.
+	AutoRequirePathParams bool
+	// Logger receives build progress reports and warnings, prefixed by WARNING. Nothing is reported by default.
+	Logger func(msg string)
+	// FS receives the output files, which are written to the OS file system by default.
+	FS WriteFS
+	// StampBuildInfo adds the x-generated-at timestamp and x-generator version extensions to the info.
+	// It is disabled by default, as it makes the output change on every build.
+	StampBuildInfo bool
+	// ComponentsOnly emits only the components section, as a fragment other documents can reference,
+	// e.g. a shared schema library. Info, servers, tags and paths are left out. Can not be combined with Split.
+	ComponentsOnly bool
+	// LineEnding sets the line endings of the output files, which are LF by default regardless of the platform.
+	LineEnding LineEnding
+	// ForceBlockStyle emits all non-empty sequences and mappings in block style, e.g. short enums.
+	// Empty ones are still emitted as [] and {}, as YAML has no block style for these.
+	ForceBlockStyle bool
+	// HeaderComment is prepended to the YAML output files as a comment, one per line,
+	// e.g. "Code generated by go-oas-docs; DO NOT EDIT." It is not written to JSON output.
+	HeaderComment string
+}
+
+// WriteFS represents a file system the output files are written to, enabling e.g. in-memory builds in tests.
+type WriteFS interface {
+	Create(name string) (io.WriteCloser, error)
+	MkdirAll(path string, perm os.FileMode) error
+}
+
+type osFS struct{}
+
+func (osFS) Create(name string) (io.WriteCloser, error) {
+	return os.Create(name) //nolint:wrapcheck //wrapped by the caller.
+}
+
+func (osFS) MkdirAll(path string, perm os.FileMode) error {
+	return os.MkdirAll(path, perm) //nolint:wrapcheck //wrapped by the caller.
+}
+
+func (cb ConfigBuilder) fs() WriteFS {
+	if cb.FS == nil {
+		return osFS{}
+	}
+
+	return cb.FS
+}
+
+// logf reports build progress, if the Logger is set.
+func (cb ConfigBuilder) logf(format string, args ...interface{}) {
+	if cb.Logger != nil {
+		cb.Logger(fmt.Sprintf(format, args...))
+	}
+}
+
+// warnf reports a build warning, if the Logger is set.
+func (cb ConfigBuilder) warnf(format string, args ...interface{}) {
+	if cb.Logger != nil {
+		cb.Logger("WARNING: " + fmt.Sprintf(format, args...))
+	}
+}
+
+// TrailingSlashMode represents the way trailing slashes of routes are normalized.
+type TrailingSlashMode int
+
+// Supported TrailingSlashMode values.
+const (
+	TrailingSlashUntouched TrailingSlashMode = iota
+	TrailingSlashStrip
+	TrailingSlashAppend
+)
+
+// normalize strips or appends a single trailing slash, while the root route is always kept as is.
+func (tsm TrailingSlashMode) normalize(route string) string {
+	if route == fwSlashSuffix {
+		return route
+	}
+
+	switch tsm {
+	case TrailingSlashStrip:
+		return strings.TrimSuffix(route, fwSlashSuffix)
+	case TrailingSlashAppend:
+		if strings.HasSuffix(route, fwSlashSuffix) {
+			return route
+		}
+
+		return route + fwSlashSuffix
+	case TrailingSlashUntouched:
+		return route
+	default:
+		return route
+	}
+}
+
+// LineEnding represents the line endings used in the output files.
+type LineEnding int
+
+// Supported LineEnding values.
+const (
+	LineEndingLF LineEnding = iota
+	LineEndingCRLF
+)
+
+func (le LineEnding) normalize(content []byte) []byte {
+	lf := bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+
+	switch le {
+	case LineEndingCRLF:
+		return bytes.ReplaceAll(lf, []byte("\n"), []byte("\r\n"))
+	case LineEndingLF:
+		return lf
+	default:
+		return lf
+	}
 }
 
 func (cb ConfigBuilder) getPath() string {
 	return cb.CustomPath
 }
 
+// validateOutputOptions checks that the options shaping the output files do not conflict.
+func (cb ConfigBuilder) validateOutputOptions() error {
+	if cb.Split && cb.ComponentsOnly {
+		return fmt.Errorf("split and components only output can not be combined")
+	}
+
+	if cb.Split && cb.getPath() == stdoutPath {
+		return fmt.Errorf("split output can not be written to the standard output")
+	}
+
+	return nil
+}
+
+func (cb ConfigBuilder) isExcluded(path *Path) bool {
+	for _, excluded := range cb.ExcludeTags {
+		for _, tag := range path.Tags {
+			if tag == excluded {
+				return true
+			}
+		}
+	}
+
+	if cb.ExcludeWhere != nil {
+		return cb.ExcludeWhere(*path)
+	}
+
+	return false
+}
+
+func getConfigFromFirstElement(cbs []ConfigBuilder) ConfigBuilder {
+	if len(cbs) == 0 {
+		return ConfigBuilder{}
+	}
+
+	return cbs[0]
+}
+
 func getPathFromFirstElement(cbs []ConfigBuilder) string {
 	if len(cbs) == 0 {
 		return defaultDocsOutPath
@@ -34,42 +217,282 @@ func getPathFromFirstElement(cbs []ConfigBuilder) string {
 
 // BuildDocs marshals the OAS struct to YAML and saves it to the chosen output file.
 //
-// Returns an error if there is any.
+// Returns an error if there is any, which matches one of ErrRead, ErrValidation, ErrMarshal or ErrWrite.
 func (o *OAS) BuildDocs(conf ...ConfigBuilder) error {
-	o.initCallStackForRoutes()
+	if err := getConfigFromFirstElement(conf).validateOutputOptions(); err != nil {
+		return newBuildError(ErrValidation, err)
+	}
+
+	build, err := o.prepareForBuild(getConfigFromFirstElement(conf))
+	if err != nil {
+		return err
+	}
+
+	if getConfigFromFirstElement(conf).Split {
+		return build.buildSplitDocs(getPathFromFirstElement(conf), getConfigFromFirstElement(conf))
+	}
 
-	yml, err := marshalToYAML(o)
+	yml, err := marshalToYAML(build, getConfigFromFirstElement(conf))
 	if err != nil {
-		return fmt.Errorf("marshaling issue occurred: %w", err)
+		return newBuildError(ErrMarshal, err)
 	}
 
-	err = createYAMLOutFile(getPathFromFirstElement(conf), yml)
+	getConfigFromFirstElement(conf).logf("writing docs to %s", getPathFromFirstElement(conf))
+
+	err = createYAMLOutFile(getConfigFromFirstElement(conf), getPathFromFirstElement(conf), yml)
 	if err != nil {
-		return fmt.Errorf("an issue occurred while saving to YAML output: %w", err)
+		return newBuildError(ErrWrite, err)
 	}
 
 	return nil
 }
 
-func marshalToYAML(oas *OAS) ([]byte, error) {
-	transformedOAS := oas.transformToHybridOAS()
+// MustBuildDocs is like BuildDocs but panics if the docs cannot be built.
+// It simplifies safe initialization in generators and one-off scripts.
+func (o *OAS) MustBuildDocs(conf ...ConfigBuilder) {
+	if err := o.BuildDocs(conf...); err != nil {
+		panic("docs: MustBuildDocs: " + err.Error())
+	}
+}
+
+// prepareForBuild calls the registered routes, applies the configured fixes to the copy of the OAS to be marshaled,
+// see buildCopy, and validates it. The info description of the copy is read from the file.
+func (o *OAS) prepareForBuild(cb ConfigBuilder) (*OAS, error) {
+	cb.logf("calling registered routes for %d paths", len(o.Paths))
+	o.initCallStackForRoutes()
+
+	var description []byte
+
+	if !isStrEmpty(o.Info.DescriptionFile) {
+		cb.logf("reading info description from %s", o.Info.DescriptionFile)
+
+		var err error
+
+		description, err = os.ReadFile(o.Info.DescriptionFile)
+		if err != nil {
+			return nil, newBuildError(ErrRead, err)
+		}
+	}
+
+	build := o.buildCopy()
+	if description != nil {
+		build.Info.Description = string(description)
+	}
+
+	if cb.AutoRequirePathParams {
+		build.requirePathParameters(cb)
+	}
+
+	err := build.Validate()
+	if err == nil {
+		err = build.validateNormalizedRoutes(cb)
+	}
+
+	if err == nil {
+		err = build.validateCasedOperationIDs(cb)
+	}
+
+	if err == nil {
+		err = build.validateOperationIDPattern(cb)
+	}
+
+	if err != nil {
+		cb.logf("validation failed: %v", err)
+
+		return nil, newBuildError(ErrValidation, err)
+	}
+
+	cb.logf("validation passed")
+	build.warnUnknownMediaTypes(cb)
+
+	if cb.WarnUnusedSecuritySchemes {
+		build.warnUnusedSecuritySchemes(cb)
+	}
+
+	return build, nil
+}
+
+// buildCopy returns a copy of the OAS with the build time conversions and defaults applied,
+// so that building never modifies the OAS itself.
+func (o *OAS) buildCopy() *OAS {
+	copied := o.withMarshaledValues()
+	copied.applyDefaultMediaType()
+
+	return copied
+}
+
+// defaultMediaType returns the media type of content types with a schema but no name.
+func (o *OAS) defaultMediaType() string {
+	if isStrEmpty(o.DefaultMediaType) {
+		return defaultMediaType
+	}
+
+	return o.DefaultMediaType
+}
+
+// applyDefaultMediaType names the content types with a schema but no name by the default media type.
+// Explicitly named content types are left untouched.
+func (o *OAS) applyDefaultMediaType() {
+	mediaType := o.defaultMediaType()
+
+	contents := make([]ContentTypes, 0, len(o.Paths)+len(o.DefaultResponses))
+
+	for i := range o.Paths {
+		contents = append(contents, o.Paths[i].contents()...)
+	}
+
+	for i := range o.DefaultResponses {
+		contents = append(contents, o.DefaultResponses[i].Content)
+	}
+
+	for _, component := range o.Components {
+		for _, resp := range component.Responses {
+			contents = append(contents, resp.Content)
+		}
+
+		for _, item := range component.PathItems {
+			for i := range item.Operations {
+				contents = append(contents, item.Operations[i].contents()...)
+			}
+		}
+	}
+
+	for _, content := range contents {
+		for i := range content {
+			if isStrEmpty(content[i].Name) && content[i].hasSchema() {
+				content[i].Name = mediaType
+			}
+		}
+	}
+}
+
+func marshalToYAML(oas *OAS, conf ...ConfigBuilder) ([]byte, error) {
+	cb := getConfigFromFirstElement(conf)
+	if cb.ComponentsOnly {
+		return marshalComponentsToYAML(oas, cb)
+	}
+
+	return marshalHybridToYAML(oas.transformToHybridOAS(cb), cb)
+}
+
+// marshalComponentsToYAML marshals only the components of the OAS, keeping the refs between them valid,
+// as they are placed under the same components key.
+func marshalComponentsToYAML(oas *OAS, cb ConfigBuilder) ([]byte, error) {
+	components := oas.Components
+	if cb.PruneComponents {
+		components = oas.prunedComponents(cb)
+	}
+
+	fragment := map[string]interface{}{keyComponents: makeComponentsMap(&components, oas.OASVersion)}
+
+	return marshalStyledYAML(fragment, cb, oas.rawFragments...)
+}
+
+// withHeaderComment prepends the configured header comment to the YAML, prefixing each of its lines with #.
+func (cb ConfigBuilder) withHeaderComment(yml []byte) []byte {
+	if isStrEmpty(cb.HeaderComment) {
+		return yml
+	}
+
+	var header strings.Builder
+
+	for _, line := range strings.Split(strings.TrimRight(cb.HeaderComment, "\n"), "\n") {
+		header.WriteString(strings.TrimRight("# "+line, " ") + "\n")
+	}
+
+	return append([]byte(header.String()), yml...)
+}
+
+// forceBlockStyle resets the style of all sequences and mappings, so that non-empty ones are emitted in block style.
+func forceBlockStyle(node *yaml.Node) {
+	if node.Kind == yaml.SequenceNode || node.Kind == yaml.MappingNode {
+		node.Style &^= yaml.FlowStyle
+	}
+
+	for _, child := range node.Content {
+		forceBlockStyle(child)
+	}
+}
+
+func marshalHybridToYAML(transformedOAS hybridOAS, cb ConfigBuilder) ([]byte, error) {
+	return marshalStyledYAML(transformedOAS, cb, transformedOAS.rawFragments...)
+}
+
+// marshalStyledYAML marshals the value, going through a yaml.Node when the config requires restyling the output
+// or there are raw fragments to inject.
+func marshalStyledYAML(v interface{}, cb ConfigBuilder, fragments ...rawFragment) ([]byte, error) {
+	if !cb.DescriptionsAsComments && !cb.ForceBlockStyle && len(fragments) == 0 {
+		yml, err := yaml.Marshal(v)
+		if err != nil {
+			return yml, fmt.Errorf("failed marshaling to yaml: %w", err)
+		}
+
+		return cb.withHeaderComment(yml), nil
+	}
+
+	var node yaml.Node
 
-	yml, err := yaml.Marshal(transformedOAS)
+	err := node.Encode(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed encoding to yaml node: %w", err)
+	}
+
+	err = injectRawFragments(&node, fragments)
+	if err != nil {
+		return nil, err
+	}
+
+	if cb.DescriptionsAsComments {
+		addDescriptionComments(&node)
+	}
+
+	if cb.ForceBlockStyle {
+		forceBlockStyle(&node)
+	}
+
+	yml, err := yaml.Marshal(&node)
 	if err != nil {
 		return yml, fmt.Errorf("failed marshaling to yaml: %w", err)
 	}
 
-	return yml, nil
+	return cb.withHeaderComment(yml), nil
+}
+
+// ToMap returns the OAS in the same structure which is fed to the YAML marshaler, as a generic map.
+//
+// Registered routes are not called, so make sure that Paths are already in their final state.
+func (o *OAS) ToMap() (map[string]interface{}, error) {
+	yml, err := marshalToYAML(o.buildCopy())
+	if err != nil {
+		return nil, newBuildError(ErrMarshal, err)
+	}
+
+	var generic map[string]interface{}
+
+	err = yaml.Unmarshal(yml, &generic)
+	if err != nil {
+		return nil, fmt.Errorf("failed unmarshaling to a generic map: %w", err)
+	}
+
+	return generic, nil
 }
 
-func createYAMLOutFile(outPath string, marshaledYAML []byte) error {
-	outYAML, err := os.Create(outPath)
+func createYAMLOutFile(cb ConfigBuilder, outPath string, marshaledYAML []byte) error {
+	if outPath == stdoutPath {
+		return writeYAMLOut(cb, os.Stdout, marshaledYAML)
+	}
+
+	outYAML, err := cb.fs().Create(outPath)
 	if err != nil {
 		return fmt.Errorf("failed creating yaml output file: %w", err)
 	}
 	defer outYAML.Close()
 
-	err = writeAndFlush(marshaledYAML, outYAML)
+	return writeYAMLOut(cb, outYAML, marshaledYAML)
+}
+
+func writeYAMLOut(cb ConfigBuilder, outYAML io.Writer, marshaledYAML []byte) error {
+	err := writeAndFlush(cb.LineEnding.normalize(marshaledYAML), outYAML)
 	if err != nil {
 		return fmt.Errorf("writing issue occurred: %w", err)
 	}
@@ -102,74 +525,471 @@ type (
 )
 
 type hybridOAS struct {
-	OpenAPI      OASVersion    `yaml:"openapi"`
-	Info         Info          `yaml:"info"`
-	ExternalDocs ExternalDocs  `yaml:"externalDocs"`
-	Servers      Servers       `yaml:"servers"`
-	Tags         Tags          `yaml:"tags"`
-	Paths        pathsMap      `yaml:"paths"`
-	Components   componentsMap `yaml:"components"`
+	OpenAPI           OASVersion    `yaml:"openapi"`
+	Info              Info          `yaml:"info"`
+	JSONSchemaDialect string        `yaml:"jsonSchemaDialect,omitempty"`
+	ExternalDocs      ExternalDocs  `yaml:"externalDocs"`
+	Servers           Servers       `yaml:"servers"`
+	Tags              Tags          `yaml:"tags"`
+	Paths             pathsMap      `yaml:"paths"`
+	Components        componentsMap `yaml:"components,omitempty"`
+
+	rawFragments []rawFragment
+}
+
+// deduplicated returns the tags without duplicate names, keeping the first tag of each name.
+// A warning is reported for duplicates which differ from the kept one.
+func (tt Tags) deduplicated(cb ConfigBuilder) Tags {
+	seen := make(map[string]int, len(tt))
+	unique := make(Tags, 0, len(tt))
+
+	for _, tag := range tt {
+		if i, ok := seen[tag.Name]; ok {
+			if unique[i] != tag {
+				cb.warnf("tag %q is defined multiple times, keeping the first definition", tag.Name)
+			}
+
+			continue
+		}
+
+		seen[tag.Name] = len(unique)
+		unique = append(unique, tag)
+	}
+
+	return unique
 }
 
-func (o *OAS) transformToHybridOAS() hybridOAS {
+// jsonSchemaDialect returns the declared JSON Schema dialect, defaulting to the OAS 3.1 base one.
+func (o *OAS) jsonSchemaDialect() string {
+	if isStrEmpty(o.JSONSchemaDialect) {
+		return defaultJSONSchemaDialect
+	}
+
+	return o.JSONSchemaDialect
+}
+
+func (o *OAS) transformToHybridOAS(cb ConfigBuilder) hybridOAS {
 	ho := hybridOAS{}
 
 	ho.OpenAPI = o.OASVersion
 	ho.Info = o.Info
+	ho.rawFragments = o.rawFragments
+
+	if o.OASVersion.is31() {
+		ho.JSONSchemaDialect = o.jsonSchemaDialect()
+	} else {
+		ho.Info.Summary = emptyStr
+		ho.Info.License.Identifier = emptyStr
+	}
+
+	if cb.StampBuildInfo {
+		ho.Info = stampedInfo(ho.Info, time.Now())
+	}
+
 	ho.ExternalDocs = o.ExternalDocs
 	ho.Servers = o.Servers
-	ho.Tags = o.Tags
+	ho.Tags = o.Tags.deduplicated(cb)
+
+	paths := o.pathsWithDefaults()
+	ho.Paths = makeAllPathsMap(&paths, cb, o.OASVersion)
+	addPathItemsToPathsMap(ho.Paths, o.PathItems, cb)
+	cb.logf("emitting %d routes", len(ho.Paths))
+
+	components := o.Components
+	if cb.PruneComponents {
+		components = o.prunedComponents(cb)
+	}
+
+	ho.Components = makeComponentsMap(&components, o.OASVersion)
 
-	ho.Paths = makeAllPathsMap(&o.Paths)
-	ho.Components = makeComponentsMap(&o.Components)
+	schemas, _ := ho.Components[keySchemas].(map[string]interface{})
+	secSchemes, _ := ho.Components[keySecuritySchemes].(map[string]interface{})
+	cb.logf("emitting %d component schemas and %d security schemes", len(schemas), len(secSchemes))
 
 	return ho
 }
 
-func makeAllPathsMap(paths *Paths) pathsMap {
+// pathsWithDefaults returns a copy of the paths, with the document level default responses
+// and deprecation applied to each of them, unless overridden by the path itself.
+func (o *OAS) pathsWithDefaults() Paths {
+	if len(o.DefaultResponses) == 0 && !o.Deprecated {
+		return o.Paths
+	}
+
+	paths := make(Paths, len(o.Paths))
+	for i := range o.Paths {
+		paths[i] = o.Paths[i]
+		paths[i].Responses = o.Paths[i].Responses.withDefaults(o.DefaultResponses)
+
+		if o.Deprecated && paths[i].Deprecated == nil {
+			paths[i].Deprecated = &o.Deprecated
+		}
+	}
+
+	return paths
+}
+
+func makeAllPathsMap(paths *Paths, cb ConfigBuilder, ver OASVersion) pathsMap {
 	allPaths := make(pathsMap, len(*paths))
+	originalRoutes := make(map[string]string, len(*paths))
+
 	for _, path := range *paths { //nolint:gocritic //consider indexing?
-		if allPaths[path.Route] == nil {
-			allPaths[path.Route] = make(methodsMap)
+		if cb.isExcluded(&path) {
+			cb.logf("excluding %s %s", path.HTTPMethod, path.Route)
+
+			continue
 		}
 
-		pathMap := make(map[string]interface{})
-		pathMap[keyTags] = path.Tags
-		pathMap[keySummary] = path.Summary
-		pathMap[keyOperationID] = path.OperationID
-		pathMap[keySecurity] = makeSecurityMap(&path.Security)
-		pathMap[keyRequestBody] = makeRequestBodyMap(&path.RequestBody)
-		pathMap[keyResponses] = makeResponsesMap(&path.Responses)
+		route := cb.TrailingSlash.normalize(path.Route)
+		if original, ok := originalRoutes[route]; ok && original != path.Route {
+			cb.warnf("routes %q and %q collide as %q after trailing slash normalization", original, path.Route, route)
+		}
+
+		originalRoutes[route] = path.Route
+
+		if allPaths[route] == nil {
+			allPaths[route] = make(methodsMap)
+		}
 
-		allPaths[path.Route][strings.ToLower(path.HTTPMethod)] = pathMap
+		opMap := makeOperationMap(&path, ver)
+		opMap[keyOperationID] = cb.OperationIDCase.apply(path.OperationID)
+
+		allPaths[route][strings.ToLower(path.HTTPMethod)] = opMap
 	}
 
 	return allPaths
 }
 
-func makeRequestBodyMap(reqBody *RequestBody) map[string]interface{} {
+func makeOperationMap(path *Path, ver OASVersion) map[string]interface{} {
+	pathMap := make(map[string]interface{})
+	pathMap[keyTags] = uniqueStrings(path.Tags)
+	pathMap[keySummary] = path.Summary
+	pathMap[keyOperationID] = path.OperationID
+	pathMap[keySecurity] = makeSecurityMap(&path.Security)
+
+	if path.Deprecated != nil && *path.Deprecated {
+		pathMap[keyDeprecated] = true
+	}
+
+	if len(path.Parameters) > 0 {
+		pathMap[keyParameters] = makeParametersMap(&path.Parameters, ver)
+	}
+
+	pathMap[keyRequestBody] = makeRequestBodyMap(&path.RequestBody, ver)
+	pathMap[keyResponses] = makeResponsesMap(&path.Responses, ver)
+
+	if len(path.Servers) > 0 {
+		pathMap[keyServers] = path.Servers
+	}
+
+	return pathMap
+}
+
+// addPathItemsToPathsMap sets the route level fields, for routes which have at least one operation.
+func addPathItemsToPathsMap(allPaths pathsMap, items PathItems, cb ConfigBuilder) {
+	for route, item := range items {
+		if !isStrEmpty(item.Ref) {
+			allPaths[cb.TrailingSlash.normalize(route)] = methodsMap{keyRef: item.Ref}
+
+			continue
+		}
+
+		methods, ok := allPaths[cb.TrailingSlash.normalize(route)]
+		if !ok {
+			continue
+		}
+
+		if !isStrEmpty(item.Summary) {
+			methods[keySummary] = item.Summary
+		}
+
+		if !isStrEmpty(item.Description) {
+			methods[keyDescription] = item.Description
+		}
+
+		if len(item.Servers) > 0 {
+			methods[keyServers] = item.Servers
+		}
+	}
+}
+
+func makeParametersMap(params *Parameters, ver OASVersion) []map[string]interface{} {
+	paramsMaps := make([]map[string]interface{}, 0, len(*params))
+
+	for _, param := range *params {
+		paramMap := make(map[string]interface{})
+		paramMap[keyName] = param.Name
+		paramMap[keyIn] = param.In
+
+		if !isStrEmpty(param.Description) {
+			paramMap[keyDescription] = param.Description
+		}
+
+		if param.Required {
+			paramMap[keyRequired] = true
+		}
+
+		if param.Deprecated {
+			paramMap[keyDeprecated] = true
+		}
+
+		if param.AllowEmptyValue {
+			paramMap[keyAllowEmptyValue] = true
+		}
+
+		if param.AllowReserved {
+			paramMap[keyAllowReserved] = true
+		}
+
+		if param.Schema != nil {
+			paramMap[keySchema] = makeSchemaMap(param.Schema, ver)
+		}
+
+		if len(param.Content) > 0 {
+			paramMap[keyContent] = makeContentSchemaMap(param.Content, ver)
+		}
+
+		paramsMaps = append(paramsMaps, paramMap)
+	}
+
+	return paramsMaps
+}
+
+// makeSchemaMap builds a schema map, emitting only the keys that are set.
+func makeSchemaMap(s *Schema, ver OASVersion) map[string]interface{} {
+	schema := make(map[string]interface{})
+
+	if !isStrEmpty(s.Ref) {
+		schema[keyRef] = s.Ref
+	}
+
+	switch {
+	case s.Nullable && ver.is31() && !isStrEmpty(s.Type):
+		schema[keyType] = []string{s.Type, typeNull}
+	case !isStrEmpty(s.Type):
+		schema[keyType] = s.Type
+	}
+
+	if s.Nullable && !ver.is31() {
+		schema[keyNullable] = true
+	}
+
+	if !isStrEmpty(s.Format) {
+		schema[keyFormat] = s.Format
+	}
+
+	if !isStrEmpty(s.Description) {
+		schema[keyDescription] = s.Description
+	}
+
+	addStringContentToSchemaMap(schema, s, ver)
+
+	if len(s.Properties) > 0 {
+		schema[keyProperties] = makePropertiesMap(&s.Properties, ver)
+	}
+
+	if len(s.Required) > 0 {
+		schema[keyRequired] = s.Required
+	}
+
+	if s.Items != nil {
+		schema[keyItems] = makeSchemaMap(s.Items, ver)
+	}
+
+	addValueConstraintsToSchemaMap(schema, s, ver)
+
+	if !s.XML.isEmpty() {
+		schema[keyXML] = s.XML
+	}
+
+	if len(s.PatternProperties) > 0 && ver.is31() {
+		patternProps := make(map[string]interface{}, len(s.PatternProperties))
+		for pattern, ps := range s.PatternProperties {
+			if ps != nil {
+				patternProps[pattern] = makeSchemaMap(ps, ver)
+			}
+		}
+
+		if len(patternProps) > 0 {
+			schema[keyPatternProperties] = patternProps
+		}
+	}
+
+	addCompositionToSchemaMap(schema, s, ver)
+
+	return schema
+}
+
+// enumValues is marshaled as a flow sequence when short, and as a block sequence with one value per line otherwise,
+// keeping diffs of long enums readable.
+type enumValues []string
+
+func (ev enumValues) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	if len(ev) < flowEnumMaxValues {
+		node.Style = yaml.FlowStyle
+	}
+
+	for _, value := range ev {
+		var item yaml.Node
+		if err := item.Encode(value); err != nil {
+			return nil, fmt.Errorf("failed encoding enum value %q: %w", value, err)
+		}
+
+		node.Content = append(node.Content, &item)
+	}
+
+	return node, nil
+}
+
+func addStringContentToSchemaMap(schema map[string]interface{}, s *Schema, ver OASVersion) {
+	if !ver.is31() {
+		return
+	}
+
+	if !isStrEmpty(s.ContentEncoding) {
+		schema[keyContentEncoding] = s.ContentEncoding
+	}
+
+	if !isStrEmpty(s.ContentMediaType) {
+		schema[keyContentMediaType] = s.ContentMediaType
+	}
+}
+
+func addValueConstraintsToSchemaMap(schema map[string]interface{}, s *Schema, ver OASVersion) {
+	if len(s.Enum) > 0 {
+		schema[keyEnum] = enumValues(s.Enum)
+	}
+
+	if s.Default != nil {
+		schema[keyDefault] = s.Default
+	}
+
+	if s.UniqueItems {
+		schema[keyUniqueItems] = true
+	}
+
+	if s.Deprecated {
+		schema[keyDeprecated] = true
+	}
+
+	if s.ReadOnly {
+		schema[keyReadOnly] = true
+	}
+
+	if s.WriteOnly {
+		schema[keyWriteOnly] = true
+	}
+
+	addExamplesToSchemaMap(schema, s, ver)
+
+	if s.Const != nil && ver.is31() {
+		schema[keyConst] = s.Const
+	}
+}
+
+// addExamplesToSchemaMap emits examples as an array for OAS 3.1, which deprecates the single example.
+// For older versions, only the single example is emitted, falling back to the first one of the examples.
+func addExamplesToSchemaMap(schema map[string]interface{}, s *Schema, ver OASVersion) {
+	if ver.is31() {
+		examples := make([]interface{}, 0, len(s.Examples)+1)
+		if s.Example != nil {
+			examples = append(examples, s.Example)
+		}
+
+		examples = append(examples, s.Examples...)
+
+		if len(examples) > 0 {
+			schema[keyExamples] = examples
+		}
+
+		return
+	}
+
+	switch {
+	case s.Example != nil:
+		schema[keyExample] = s.Example
+	case len(s.Examples) > 0:
+		schema[keyExample] = s.Examples[0]
+	}
+}
+
+func addCompositionToSchemaMap(schema map[string]interface{}, s *Schema, ver OASVersion) {
+	compositions := []struct {
+		key     string
+		schemas Schemas
+	}{
+		{keyOneOf, s.OneOf},
+		{keyAnyOf, s.AnyOf},
+		{keyAllOf, s.AllOf},
+	}
+
+	for _, composition := range compositions {
+		if len(composition.schemas) == 0 {
+			continue
+		}
+
+		subSchemas := make([]map[string]interface{}, 0, len(composition.schemas))
+		for i := range composition.schemas {
+			subSchemas = append(subSchemas, makeSchemaMap(&composition.schemas[i], ver))
+		}
+
+		schema[composition.key] = subSchemas
+	}
+
+	if s.Discriminator != nil {
+		schema[keyDiscriminator] = s.Discriminator
+	}
+}
+
+func makeRequestBodyMap(reqBody *RequestBody, ver OASVersion) map[string]interface{} {
 	reqBodyMap := make(map[string]interface{})
 
 	reqBodyMap[keyDescription] = reqBody.Description
-	reqBodyMap[keyContent] = makeContentSchemaMap(reqBody.Content)
+	reqBodyMap[keyContent] = makeContentSchemaMap(reqBody.Content, ver)
+
+	if reqBody.Required {
+		reqBodyMap[keyRequired] = true
+	}
 
 	return reqBodyMap
 }
 
-func makeResponsesMap(responses *Responses) map[uint]interface{} {
-	responsesMap := make(map[uint]interface{}, len(*responses))
-
-	for _, resp := range *responses {
-		codeBodyMap := make(map[string]interface{})
-		codeBodyMap[keyDescription] = resp.Description
-		codeBodyMap[keyContent] = makeContentSchemaMap(resp.Content)
+// makeResponsesMap keys responses by their status codes (or ranges) as strings, so that these are always emitted quoted.
+func makeResponsesMap(responses *Responses, ver OASVersion) map[string]interface{} {
+	responsesMap := make(map[string]interface{}, len(*responses))
 
-		responsesMap[resp.Code] = codeBodyMap
+	for i := range *responses {
+		resp := &(*responses)[i]
+		responsesMap[resp.key()] = makeResponseMap(resp, ver)
 	}
 
 	return responsesMap
 }
 
+func makeResponseMap(resp *Response, ver OASVersion) map[string]interface{} {
+	codeBodyMap := make(map[string]interface{})
+
+	if !isStrEmpty(resp.Ref) {
+		codeBodyMap[keyRef] = resp.Ref
+
+		if ver.is31() && !isStrEmpty(resp.Description) {
+			codeBodyMap[keyDescription] = resp.Description
+		}
+
+		return codeBodyMap
+	}
+
+	codeBodyMap[keyDescription] = resp.Description
+	codeBodyMap[keyContent] = makeContentSchemaMap(resp.Content, ver)
+
+	if len(resp.Headers) > 0 {
+		codeBodyMap[keyHeaders] = makeHeadersMap(resp.Headers, ver)
+	}
+
+	return codeBodyMap
+}
+
 func makeSecurityMap(se *SecurityEntities) pathSecurityMaps {
 	securityMaps := make(pathSecurityMaps, 0, len(*se))
 
@@ -183,15 +1003,31 @@ func makeSecurityMap(se *SecurityEntities) pathSecurityMaps {
 	return securityMaps
 }
 
-func makeContentSchemaMap(content ContentTypes) map[string]interface{} {
+// makeContentSchemaMap omits the schema of content types without one, emitting an empty media type object,
+// e.g. for binary downloads. Validate rejects content with both an inline schema and a ref.
+func makeContentSchemaMap(content ContentTypes, ver OASVersion) map[string]interface{} {
 	contentSchemaMap := make(map[string]interface{})
 
 	for _, ct := range content {
-		refMap := make(map[string]string)
-		refMap[keyRef] = ct.Schema
+		schemaMap := make(map[string]interface{})
+
+		switch {
+		case ct.InlineSchema != nil:
+			schemaMap[keySchema] = makeSchemaMap(ct.InlineSchema, ver)
+		case !isStrEmpty(ct.Schema):
+			refMap := make(map[string]string)
+			refMap[keyRef] = ct.Schema
+
+			schemaMap[keySchema] = refMap
+		}
+
+		if len(ct.Examples) > 0 {
+			schemaMap[keyExamples] = ct.Examples
+		}
 
-		schemaMap := make(map[string]map[string]string)
-		schemaMap["schema"] = refMap
+		if len(ct.Encoding) > 0 {
+			schemaMap[keyEncoding] = makeEncodingMap(ct.Encoding, ver)
+		}
 
 		contentSchemaMap[ct.Name] = schemaMap
 	}
@@ -199,63 +1035,191 @@ func makeContentSchemaMap(content ContentTypes) map[string]interface{} {
 	return contentSchemaMap
 }
 
-func makeComponentsMap(components *Components) componentsMap {
-	cm := make(componentsMap, len(*components))
+func makeEncodingMap(encoding map[string]Encoding, ver OASVersion) map[string]interface{} {
+	encodingMap := make(map[string]interface{}, len(encoding))
 
-	for _, component := range *components {
-		cm[keySchemas] = makeComponentSchemasMap(&component.Schemas)
-		cm[keySecuritySchemes] = makeComponentSecuritySchemesMap(&component.SecuritySchemes)
+	for name, enc := range encoding {
+		encMap := make(map[string]interface{})
+
+		if !isStrEmpty(enc.ContentType) {
+			encMap[keyContentType] = enc.ContentType
+		}
+
+		if len(enc.Headers) > 0 {
+			encMap[keyHeaders] = makeHeadersMap(enc.Headers, ver)
+		}
+
+		if !isStrEmpty(enc.Style) {
+			encMap[keyStyle] = enc.Style
+		}
+
+		if enc.Explode != nil {
+			encMap[keyExplode] = *enc.Explode
+		}
+
+		if enc.AllowReserved {
+			encMap[keyAllowReserved] = true
+		}
+
+		encodingMap[name] = encMap
 	}
 
-	return cm
+	return encodingMap
 }
 
-func makePropertiesMap(properties *SchemaProperties) map[string]interface{} {
-	propertiesMap := make(map[string]interface{}, len(*properties))
+func makeHeadersMap(headers Headers, ver OASVersion) map[string]interface{} {
+	headersMap := make(map[string]interface{}, len(headers))
 
-	for _, prop := range *properties {
-		propMap := make(map[string]interface{})
+	for name, header := range headers {
+		headerMap := make(map[string]interface{})
 
-		if !isStrEmpty(prop.Type) {
-			propMap[keyType] = prop.Type
+		if !isStrEmpty(header.Description) {
+			headerMap[keyDescription] = header.Description
 		}
 
-		if !isStrEmpty(prop.Format) {
-			propMap[keyFormat] = prop.Format
+		if header.Required {
+			headerMap[keyRequired] = true
 		}
 
-		if !isStrEmpty(prop.Description) {
-			propMap[keyDescription] = prop.Description
+		if header.Schema != nil {
+			headerMap[keySchema] = makeSchemaMap(header.Schema, ver)
 		}
 
-		if len(prop.Enum) > 0 {
-			propMap[keyEnum] = prop.Enum
+		if header.Example != nil {
+			headerMap[keyExample] = header.Example
 		}
 
-		if prop.Default != nil {
-			propMap[keyDefault] = prop.Default
+		headersMap[name] = headerMap
+	}
+
+	return headersMap
+}
+
+func makeComponentsMap(components *Components, ver OASVersion) componentsMap {
+	cm := make(componentsMap, len(*components))
+	schemas := make(map[string]interface{})
+	securitySchemes := make(map[string]interface{})
+	pathItems := make(map[string]interface{})
+	responses := make(map[string]interface{})
+
+	// Entries of all components are merged, so that none of them gets overwritten by the following one.
+	for _, component := range *components {
+		for name, schema := range makeComponentSchemasMap(&component.Schemas, ver) {
+			schemas[name] = schema
+		}
+
+		for name, scheme := range makeComponentSecuritySchemesMap(&component.SecuritySchemes) {
+			securitySchemes[name] = scheme
 		}
 
-		propertiesMap[prop.Name] = propMap
+		for name, item := range component.PathItems {
+			pathItems[name] = makeComponentPathItemMap(&item, ver)
+		}
+
+		for name, resp := range component.Responses {
+			responses[name] = makeResponseMap(&resp, ver)
+		}
 	}
 
-	return propertiesMap
+	if len(responses) > 0 {
+		cm[keyResponses] = responses
+	}
+
+	// Empty component types are omitted, and so is the whole components object if all of them are empty.
+	if len(schemas) > 0 {
+		cm[keySchemas] = schemas
+	}
+
+	if len(securitySchemes) > 0 {
+		cm[keySecuritySchemes] = securitySchemes
+	}
+
+	if len(pathItems) > 0 && ver.is31() {
+		cm[keyPathItems] = pathItems
+	}
+
+	return cm
 }
 
-func makeComponentSchemasMap(schemas *Schemas) map[string]interface{} {
-	schemesMap := make(map[string]interface{}, len(*schemas))
+func makeComponentPathItemMap(item *ComponentPathItem, ver OASVersion) methodsMap {
+	itemMap := make(methodsMap, len(item.Operations))
 
-	for _, s := range *schemas {
-		scheme := make(map[string]interface{})
-		scheme[keyType] = s.Type
-		scheme[keyProperties] = makePropertiesMap(&s.Properties)
-		scheme[keyRef] = s.Ref
+	if !isStrEmpty(item.Summary) {
+		itemMap[keySummary] = item.Summary
+	}
+
+	if !isStrEmpty(item.Description) {
+		itemMap[keyDescription] = item.Description
+	}
 
-		if s.XML.Name != "" {
-			scheme[keyXML] = s.XML
+	if len(item.Servers) > 0 {
+		itemMap[keyServers] = item.Servers
+	}
+
+	for i := range item.Operations {
+		op := &item.Operations[i]
+		itemMap[strings.ToLower(op.HTTPMethod)] = makeOperationMap(op, ver)
+	}
+
+	return itemMap
+}
+
+// propertiesMap is marshaled as a mapping keeping the declared order of the properties,
+// as opposed to Go maps which are marshaled with sorted keys.
+type propertiesMap []propertyEntry
+
+type propertyEntry struct {
+	name   string
+	schema map[string]interface{}
+}
+
+// get returns the schema map of the property with the given name.
+func (pm propertiesMap) get(name string) (map[string]interface{}, bool) {
+	for _, entry := range pm {
+		if entry.name == name {
+			return entry.schema, true
 		}
+	}
+
+	return nil, false
+}
+
+func (pm propertiesMap) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
 
-		schemesMap[s.Name] = scheme
+	for _, entry := range pm {
+		var key, value yaml.Node
+		if err := key.Encode(entry.name); err != nil {
+			return nil, fmt.Errorf("failed encoding property name %q: %w", entry.name, err)
+		}
+
+		if err := value.Encode(entry.schema); err != nil {
+			return nil, fmt.Errorf("failed encoding property %q: %w", entry.name, err)
+		}
+
+		node.Content = append(node.Content, &key, &value)
+	}
+
+	return node, nil
+}
+
+func makePropertiesMap(properties *Properties, ver OASVersion) propertiesMap {
+	propsMap := make(propertiesMap, 0, len(*properties))
+
+	for i := range *properties {
+		prop := &(*properties)[i]
+		propsMap = append(propsMap, propertyEntry{name: prop.Name, schema: makeSchemaMap(&prop.Schema, ver)})
+	}
+
+	return propsMap
+}
+
+func makeComponentSchemasMap(schemas *Schemas, ver OASVersion) map[string]interface{} {
+	schemesMap := make(map[string]interface{}, len(*schemas))
+
+	for i := range *schemas {
+		s := &(*schemas)[i]
+		schemesMap[s.Name] = makeSchemaMap(s, ver)
 	}
 
 	return schemesMap
@@ -269,7 +1233,9 @@ func makeComponentSecuritySchemesMap(secSchemes *SecuritySchemes) map[string]int
 
 		lenFlows := len(ss.Flows)
 
-		if !isStrEmpty(ss.Name) && lenFlows == 0 {
+		isOpenIDConnect := ss.Type == securityTypeOpenIDConnect
+
+		if !isStrEmpty(ss.Name) && lenFlows == 0 && !isOpenIDConnect {
 			scheme[keyName] = ss.Name
 		}
 
@@ -285,6 +1251,10 @@ func makeComponentSecuritySchemesMap(secSchemes *SecuritySchemes) map[string]int
 			scheme[keyFlows] = makeFlowsMap(&ss.Flows)
 		}
 
+		if isOpenIDConnect {
+			scheme[keyOpenIDConnectURL] = ss.OpenIDConnectURL
+		}
+
 		secSchemesMap[ss.Name] = scheme
 	}
 
@@ -322,6 +1292,25 @@ func makeSecurityScopesMap(scopes *SecurityScopes) map[string]interface{} {
 
 const emptyStr = ""
 
+// uniqueStrings returns the strings without duplicates, preserving the order of first occurrences.
+func uniqueStrings(ss []string) []string {
+	if len(ss) < 2 { //nolint:gomnd //nothing to deduplicate.
+		return ss
+	}
+
+	seen := make(map[string]bool, len(ss))
+	unique := make([]string, 0, len(ss))
+
+	for _, s := range ss {
+		if !seen[s] {
+			seen[s] = true
+			unique = append(unique, s)
+		}
+	}
+
+	return unique
+}
+
 func isStrEmpty(s string) bool {
 	return s == emptyStr
 }
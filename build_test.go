@@ -1,6 +1,17 @@
 package docs
 
-import "testing"
+import (
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
 
 func TestUnitBuild(t *testing.T) {
 	t.Parallel()
@@ -14,19 +25,23 @@ func TestUnitBuild(t *testing.T) {
 	component := Component{
 		Schemas: Schemas{Schema{
 			Name: "schema_testing",
-			Properties: SchemaProperties{
-				SchemaProperty{
-					Name:        "EnumProp",
-					Type:        "enum",
-					Description: "short desc",
-					Enum:        []string{"enum", "test", "strSlc"},
+			Properties: Properties{
+				Property{
+					Name: "EnumProp",
+					Schema: Schema{
+						Type:        "enum",
+						Description: "short desc",
+						Enum:        []string{"enum", "test", "strSlc"},
+					},
 				},
-				SchemaProperty{
-					Name:        "intProp",
-					Type:        "integer",
-					Format:      "int64",
-					Description: "short desc",
-					Default:     1337,
+				Property{
+					Name: "intProp",
+					Schema: Schema{
+						Type:        "integer",
+						Format:      "int64",
+						Description: "short desc",
+						Default:     1337,
+					},
 				},
 			},
 			XML: XMLEntry{Name: "XML entry test"},
@@ -73,7 +88,7 @@ func setPathForTest(t *testing.T, path *Path) {
 	t.Helper()
 
 	cts := ContentTypes{ContentType{
-		Name:   "testingType",
+		Name:   "application/json",
 		Schema: "schema_testing",
 	}}
 	response := Response{
@@ -94,7 +109,7 @@ func setPathForTest(t *testing.T, path *Path) {
 		Required:    true,
 	}
 	path.Responses = responses
-	path.Security = SecurityEntities{Security{AuthName: "sec"}}
+	path.Security = SecurityEntities{Security{AuthName: "ses_scheme_testing"}}
 }
 
 func TestUnitGetPathFromFirstElem(t *testing.T) {
@@ -109,3 +124,1646 @@ func TestUnitGetPathFromFirstElem(t *testing.T) {
 }
 
 // QUICK CHECK TESTS ARE COMING WITH NEXT RELEASE.
+
+func TestUnitMakePropertiesMapFlags(t *testing.T) {
+	t.Parallel()
+
+	props := Properties{
+		Property{Name: "roles", Schema: Schema{Type: "array", UniqueItems: true}},
+		Property{Name: "tags", Schema: Schema{Type: "array", Deprecated: true}},
+	}
+
+	got := makePropertiesMap(&props, "3.0.3")
+
+	roles, _ := got.get("roles")
+	if roles[keyUniqueItems] != true {
+		t.Errorf("expected uniqueItems to be emitted for roles, got %v", roles)
+	}
+
+	tags, _ := got.get("tags")
+	if _, ok := tags[keyUniqueItems]; ok {
+		t.Errorf("expected uniqueItems to be omitted for tags, got %v", tags)
+	}
+
+	if tags[keyDeprecated] != true {
+		t.Errorf("expected deprecated to be emitted for tags, got %v", tags)
+	}
+
+	if _, ok := roles[keyDeprecated]; ok {
+		t.Errorf("expected deprecated to be omitted for roles, got %v", roles)
+	}
+}
+
+func TestUnitMakeComponentsMapMerges(t *testing.T) {
+	t.Parallel()
+
+	components := Components{
+		Component{Schemas: Schemas{Schema{Name: "User"}}},
+		Component{Schemas: Schemas{Schema{Name: "Tag"}}},
+	}
+
+	got := makeComponentsMap(&components, "3.0.3")
+
+	schemas, _ := got[keySchemas].(map[string]interface{})
+	if len(schemas) != 2 {
+		t.Errorf("expected schemas of all components to be merged, got %v", schemas)
+	}
+}
+
+func TestUnitToMap(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.SetOASVersion("3.0.3")
+	o.Info.Title = "ToMap Testing"
+	o.Paths = Paths{Path{Route: "/users", HTTPMethod: "GET", Summary: "Get Users"}}
+
+	got, err := o.ToMap()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got["openapi"] != "3.0.3" {
+		t.Errorf("unexpected openapi version: %v", got["openapi"])
+	}
+
+	info, _ := got["info"].(map[string]interface{})
+	if info["title"] != "ToMap Testing" {
+		t.Errorf("unexpected info: %v", info)
+	}
+
+	paths, _ := got["paths"].(map[string]interface{})
+	users, _ := paths["/users"].(map[string]interface{})
+	get, _ := users["get"].(map[string]interface{})
+
+	if get[keySummary] != "Get Users" {
+		t.Errorf("unexpected paths: %v", paths)
+	}
+}
+
+func TestUnitMakeAllPathsMapExcludes(t *testing.T) {
+	t.Parallel()
+
+	paths := Paths{
+		Path{Route: "/users", HTTPMethod: "GET", Tags: []string{"user"}},
+		Path{Route: "/users", HTTPMethod: "POST", Tags: []string{"user", "internal"}},
+		Path{Route: "/metrics", HTTPMethod: "GET"},
+		Path{Route: "/debug", HTTPMethod: "GET"},
+	}
+	cb := ConfigBuilder{
+		ExcludeTags: []string{"internal"},
+		ExcludeWhere: func(path Path) bool {
+			return path.Route == "/debug"
+		},
+	}
+
+	got := makeAllPathsMap(&paths, cb, "3.0.3")
+
+	if len(got) != 2 || len(got["/users"]) != 1 {
+		t.Fatalf("unexpected paths: %v", got)
+	}
+
+	if _, ok := got["/users"]["post"]; ok {
+		t.Error("expected operation tagged internal to be excluded")
+	}
+
+	if _, ok := got["/debug"]; ok {
+		t.Error("expected operation matching ExcludeWhere to be excluded")
+	}
+}
+
+func TestUnitAddPathItemsToPathsMap(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Paths = Paths{
+		Path{Route: "/orders/{id}", HTTPMethod: "GET"},
+		Path{Route: "/orders/{id}", HTTPMethod: "DELETE"},
+	}
+	o.SetPathItem("/orders/{id}", PathItem{
+		Summary:     "Single order",
+		Description: "Operations on a single order",
+		Servers:     Servers{Server{URL: "https://orders.example.com"}},
+	})
+	o.SetPathItem("/unknown", PathItem{Summary: "No operations"})
+
+	got := o.transformToHybridOAS(ConfigBuilder{}).Paths
+
+	order := got["/orders/{id}"]
+	if order[keySummary] != "Single order" || order[keyDescription] != "Operations on a single order" {
+		t.Errorf("unexpected path item: %v", order)
+	}
+
+	if servers, ok := order[keyServers].(Servers); !ok || len(servers) != 1 {
+		t.Errorf("expected path item servers, got %v", order[keyServers])
+	}
+
+	if len(order) != 5 {
+		t.Errorf("expected both operations to be present, got %v", order)
+	}
+
+	if _, ok := got["/unknown"]; ok {
+		t.Error("expected path item without operations to be omitted")
+	}
+}
+
+func TestUnitAddPathItemsToPathsMapRef(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Paths = Paths{Path{Route: "/orders", HTTPMethod: "GET"}}
+	o.SetPathItem("/users", PathItem{Summary: "Users", Ref: "./paths/users.yaml"})
+
+	got := o.transformToHybridOAS(ConfigBuilder{}).Paths
+
+	if users := got["/users"]; len(users) != 1 || users[keyRef] != "./paths/users.yaml" {
+		t.Errorf("expected the path item to be emitted as a pure ref, got %v", users)
+	}
+
+	if _, ok := got["/orders"]; !ok {
+		t.Error("expected the other routes to be kept")
+	}
+}
+
+func TestUnitMakeAllPathsMapAllMethods(t *testing.T) {
+	t.Parallel()
+
+	methods := []string{
+		http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete,
+		http.MethodOptions, http.MethodHead, http.MethodPatch, http.MethodTrace,
+	}
+
+	o := New()
+	for _, method := range methods {
+		o.Paths = append(o.Paths, Path{
+			Route:      "/resources",
+			HTTPMethod: method,
+			Summary:    method + " summary",
+		})
+	}
+
+	if err := o.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	got := makeAllPathsMap(&o.Paths, ConfigBuilder{}, o.OASVersion)["/resources"]
+
+	for _, method := range methods {
+		key := strings.ToLower(method)
+
+		op, ok := got[key].(map[string]interface{})
+		if !ok || op[keySummary] != method+" summary" {
+			t.Errorf("expected %s operation under %q key, got %v", method, key, got[key])
+		}
+	}
+
+	yml, err := marshalToYAML(&o)
+	if err != nil {
+		t.Fatalf("unexpected marshaling error: %v", err)
+	}
+
+	for _, method := range methods {
+		if !strings.Contains(string(yml), "\n        "+strings.ToLower(method)+":\n") {
+			t.Errorf("expected %s to be marshaled as a lowercase key, got:\n%s", method, yml)
+		}
+	}
+}
+
+func TestUnitInfoSummaryVersioned(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Info.Summary = "Pet store API"
+
+	o.SetOASVersion("3.0.3")
+
+	if got := o.transformToHybridOAS(ConfigBuilder{}).Info.Summary; got != emptyStr {
+		t.Errorf("expected summary to be omitted for 3.0, got %q", got)
+	}
+
+	o.SetOASVersion("3.1.0")
+
+	if got := o.transformToHybridOAS(ConfigBuilder{}).Info.Summary; got != "Pet store API" {
+		t.Errorf("expected summary to be emitted for 3.1, got %q", got)
+	}
+}
+
+func TestUnitResponseCodesQuoted(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Paths = Paths{Path{
+		Route:      "/users",
+		HTTPMethod: "GET",
+		Responses:  Responses{Response{Code: 200, Description: "OK"}, Response{Code: 404, Description: "Not Found"}},
+	}}
+
+	yml, err := marshalToYAML(&o)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []string{`"200":`, `"404":`} {
+		if !strings.Contains(string(yml), want) {
+			t.Errorf("expected output to contain %s, got:\n%s", want, yml)
+		}
+	}
+}
+
+func TestUnitMakeAllPathsMapTrailingSlash(t *testing.T) {
+	t.Parallel()
+
+	paths := Paths{
+		Path{Route: "/users", HTTPMethod: "GET"},
+		Path{Route: "/users/", HTTPMethod: "POST"},
+		Path{Route: "/", HTTPMethod: "GET"},
+	}
+
+	tests := []struct {
+		mode TrailingSlashMode
+		want []string
+	}{
+		{TrailingSlashUntouched, []string{"/users", "/users/", "/"}},
+		{TrailingSlashStrip, []string{"/users", "/"}},
+		{TrailingSlashAppend, []string{"/users/", "/"}},
+	}
+
+	for _, tt := range tests {
+		got := makeAllPathsMap(&paths, ConfigBuilder{TrailingSlash: tt.mode}, "3.0.3")
+
+		if len(got) != len(tt.want) {
+			t.Errorf("mode %d: expected routes %v, got %v", tt.mode, tt.want, got)
+		}
+
+		for _, route := range tt.want {
+			if _, ok := got[route]; !ok {
+				t.Errorf("mode %d: expected route %q, got %v", tt.mode, route, got)
+			}
+		}
+	}
+}
+
+func TestUnitTrailingSlashModeNormalize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		mode        TrailingSlashMode
+		route, want string
+	}{
+		{TrailingSlashStrip, "/users/", "/users"},
+		{TrailingSlashStrip, "/users//", "/users/"},
+		{TrailingSlashStrip, "//", fwSlashSuffix},
+		{TrailingSlashStrip, fwSlashSuffix, fwSlashSuffix},
+		{TrailingSlashAppend, "/users", "/users/"},
+		{TrailingSlashAppend, "/users//", "/users//"},
+		{TrailingSlashAppend, "//", "//"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.mode.normalize(tt.route); got != tt.want {
+			t.Errorf("mode %d: normalize(%q) = %q, want %q", tt.mode, tt.route, got, tt.want)
+		}
+	}
+}
+
+func TestUnitBuildDocsTrailingSlashCollision(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Paths = Paths{
+		Path{Route: "/users", HTTPMethod: "GET"},
+		Path{Route: "/users/", HTTPMethod: "POST"},
+	}
+
+	if _, err := o.BuildDocsBytes(ConfigBuilder{TrailingSlash: TrailingSlashStrip}); err != nil {
+		t.Errorf("expected operations of different methods to be merged, got %v", err)
+	}
+
+	o.Paths = append(o.Paths, Path{Route: "/users/", HTTPMethod: "get", Tags: []string{"internal"}})
+
+	_, err := o.BuildDocsBytes(ConfigBuilder{TrailingSlash: TrailingSlashStrip})
+	if !errors.Is(err, ErrValidation) || !strings.Contains(err.Error(), "GET operations") {
+		t.Errorf("expected a validation error for the colliding operations, got %v", err)
+	}
+
+	_, err = o.BuildDocsBytes(ConfigBuilder{TrailingSlash: TrailingSlashStrip, ExcludeTags: []string{"internal"}})
+	if err != nil {
+		t.Errorf("expected excluded operations not to collide, got %v", err)
+	}
+
+	if _, err := o.BuildDocsBytes(); err != nil {
+		t.Errorf("expected untouched routes not to collide, got %v", err)
+	}
+}
+
+func TestUnitMakePropertiesMapConst(t *testing.T) {
+	t.Parallel()
+
+	props := Properties{Property{Name: "eventType", Schema: Schema{Type: "string", Const: "user.created"}}}
+
+	got30, _ := makePropertiesMap(&props, "3.0.3").get("eventType")
+	if _, ok := got30[keyConst]; ok {
+		t.Errorf("expected const to be omitted for 3.0, got %v", got30)
+	}
+
+	got31, _ := makePropertiesMap(&props, "3.1.0").get("eventType")
+	if got31[keyConst] != "user.created" {
+		t.Errorf("expected const to be emitted for 3.1, got %v", got31)
+	}
+}
+
+func TestUnitMakeSchemaMapStringContent(t *testing.T) {
+	t.Parallel()
+
+	s := Schema{Type: "string", ContentEncoding: "base64", ContentMediaType: "image/png"}
+
+	got30 := makeSchemaMap(&s, "3.0.3")
+	if _, ok := got30[keyContentEncoding]; ok {
+		t.Errorf("expected contentEncoding to be omitted for 3.0, got %v", got30)
+	}
+
+	got31 := makeSchemaMap(&s, "3.1.0")
+	if got31[keyContentEncoding] != "base64" || got31[keyContentMediaType] != "image/png" {
+		t.Errorf("expected contentEncoding and contentMediaType to be emitted for 3.1, got %v", got31)
+	}
+}
+
+func TestUnitMakeComponentSchemasMapAllOfInheritance(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Components = Components{Component{Schemas: Schemas{
+		Schema{Name: "Animal", Type: "object", Required: []string{"name"}, Properties: Properties{
+			Property{Name: "name", Schema: Schema{Type: "string", Example: "Rex"}},
+		}},
+		Schema{Name: "Dog", AllOf: Schemas{
+			Schema{Ref: "#/components/schemas/Animal"},
+			Schema{Type: "object", Properties: Properties{
+				Property{Name: "breed", Schema: Schema{Type: "string", Example: "Beagle"}},
+			}},
+		}},
+	}}}
+
+	if err := o.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	yml, err := yaml.Marshal(makeComponentSchemasMap(&o.Components[0].Schemas, "3.0.3")["Dog"])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "allOf:\n    - $ref: '#/components/schemas/Animal'\n" +
+		"    - properties:\n        breed:\n            example: Beagle\n            type: string\n      type: object\n"
+	if string(yml) != want {
+		t.Errorf("got %q, but want %q", yml, want)
+	}
+
+	example := o.GenerateExample(o.Components[0].Schemas[1], ExampleForResponse)
+	if want := map[string]interface{}{"name": "Rex", "breed": "Beagle"}; !reflect.DeepEqual(example, want) {
+		t.Errorf("expected the example to merge the inherited properties, got %v", example)
+	}
+}
+
+func TestUnitMakeSchemaMapDiscriminator(t *testing.T) {
+	t.Parallel()
+
+	s := Schema{
+		Name: "Event",
+		OneOf: Schemas{
+			Schema{Ref: "#/components/schemas/UserCreated"},
+			Schema{Ref: "#/components/schemas/UserDeleted"},
+		},
+		Discriminator: &Discriminator{
+			PropertyName: "eventType",
+			Mapping: map[string]string{
+				"user.created": "#/components/schemas/UserCreated",
+				"user.deleted": "#/components/schemas/UserDeleted",
+			},
+		},
+	}
+
+	got := makeSchemaMap(&s, "3.0.3")
+
+	oneOf, _ := got[keyOneOf].([]map[string]interface{})
+	if len(oneOf) != 2 || oneOf[0][keyRef] != "#/components/schemas/UserCreated" {
+		t.Errorf("unexpected oneOf: %v", got[keyOneOf])
+	}
+
+	if got[keyDiscriminator] != s.Discriminator {
+		t.Errorf("unexpected discriminator: %v", got[keyDiscriminator])
+	}
+
+	for _, key := range []string{keyType, keyProperties, keyRef} {
+		if _, ok := got[key]; ok {
+			t.Errorf("expected empty %s to be omitted, got %v", key, got)
+		}
+	}
+
+	yml, err := yaml.Marshal(got)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(string(yml), "propertyName: eventType") ||
+		!strings.Contains(string(yml), "user.created: '#/components/schemas/UserCreated'") {
+		t.Errorf("unexpected discriminator output:\n%s", yml)
+	}
+}
+
+func TestUnitMakeSchemaMapXML(t *testing.T) {
+	t.Parallel()
+
+	s := Schema{
+		Name: "Pets",
+		Type: "array",
+		XML:  XMLEntry{Name: "pets", Namespace: "https://example.com/schema", Prefix: "ex", Wrapped: true},
+	}
+
+	yml, err := yaml.Marshal(makeSchemaMap(&s, "3.0.3"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "xml:\n    name: pets\n    namespace: https://example.com/schema\n    prefix: ex\n    wrapped: true\n"
+	if !strings.Contains(string(yml), want) {
+		t.Errorf("unexpected xml output:\n%s", yml)
+	}
+}
+
+func TestUnitMakePropertiesMapXML(t *testing.T) {
+	t.Parallel()
+
+	props := Properties{
+		Property{Name: "id", Schema: Schema{Type: "integer", XML: XMLEntry{Attribute: true}}},
+		Property{Name: "name", Schema: Schema{Type: "string"}},
+	}
+
+	got := makePropertiesMap(&props, "3.0.3")
+
+	id, _ := got.get("id")
+	if id[keyXML] != (XMLEntry{Attribute: true}) {
+		t.Errorf("expected xml attribute to be emitted for id, got %v", id)
+	}
+
+	name, _ := got.get("name")
+	if _, ok := name[keyXML]; ok {
+		t.Errorf("expected xml to be omitted for name, got %v", name)
+	}
+}
+
+func TestUnitMakeParametersMap(t *testing.T) {
+	t.Parallel()
+
+	params := Parameters{
+		Parameter{Name: "verbose", In: "query", AllowEmptyValue: true},
+		Parameter{Name: "redirect", In: "query", AllowReserved: true, Deprecated: true, Schema: &Schema{Type: "string"}},
+		Parameter{Name: "id", In: "path", Required: true},
+	}
+
+	got := makeParametersMap(&params, "3.0.3")
+
+	if got[0][keyAllowEmptyValue] != true || got[1][keyAllowReserved] != true || got[1][keyDeprecated] != true {
+		t.Errorf("expected allowEmptyValue, allowReserved and deprecated to be emitted, got %v", got)
+	}
+
+	for _, key := range []string{keyAllowEmptyValue, keyAllowReserved, keyDeprecated} {
+		if _, ok := got[2][key]; ok {
+			t.Errorf("expected %s to be omitted when not set, got %v", key, got[2])
+		}
+	}
+}
+
+func TestUnitMakeContentSchemaMapWithoutSchema(t *testing.T) {
+	t.Parallel()
+
+	content := ContentTypes{ContentType{Name: "application/octet-stream"}}
+
+	yml, err := yaml.Marshal(makeContentSchemaMap(content, "3.0.3"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := "application/octet-stream: {}\n"; string(yml) != want {
+		t.Errorf("got %q, but want %q", yml, want)
+	}
+}
+
+func TestUnitMakeContentSchemaMapMediaTypeParameters(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Paths = Paths{Path{
+		Route:      "/users",
+		HTTPMethod: "POST",
+		RequestBody: RequestBody{Content: ContentTypes{
+			ContentType{Name: "application/json; charset=utf-8", InlineSchema: &Schema{Type: "object"}},
+			ContentType{Name: "application/json", InlineSchema: &Schema{Type: "object"}},
+		}},
+		Responses: Responses{Response{Code: 204, Description: "Created"}},
+	}}
+
+	yml, err := o.BuildDocsBytes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var generic map[string]interface{}
+	if err := yaml.Unmarshal(yml, &generic); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	users, _ := generic["paths"].(map[string]interface{})["/users"].(map[string]interface{})
+	post, _ := users["post"].(map[string]interface{})
+	content, _ := post[keyRequestBody].(map[string]interface{})[keyContent].(map[string]interface{})
+
+	for _, name := range []string{"application/json; charset=utf-8", "application/json"} {
+		if _, ok := content[name]; !ok {
+			t.Errorf("expected content %q to be kept verbatim, got %v", name, content)
+		}
+	}
+}
+
+func TestUnitBuildDocsPolymorphicResponse(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Components = Components{Component{Schemas: Schemas{
+		Schema{Name: "Order", Type: "object"},
+		Schema{Name: "Redirect", Type: "object"},
+		Schema{Name: "Unused", Type: "object"},
+	}}}
+	o.Paths = Paths{Path{
+		Route: "/orders/{id}", HTTPMethod: "GET",
+		Parameters: Parameters{Parameter{Name: "id", In: "path", Required: true, Schema: &Schema{Type: "string"}}},
+		Responses: Responses{Response{Code: 200, Description: "Order or redirect stub", Content: ContentTypes{{
+			Name: "application/json",
+			InlineSchema: &Schema{OneOf: Schemas{
+				Schema{Ref: "#/components/schemas/Order"},
+				Schema{Ref: "#/components/schemas/Redirect"},
+			}},
+		}}}},
+	}}
+
+	yml, err := o.BuildDocsBytes(ConfigBuilder{PruneComponents: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "schema:\n                                oneOf:\n" +
+		"                                    - $ref: '#/components/schemas/Order'\n" +
+		"                                    - $ref: '#/components/schemas/Redirect'\n"
+	if !strings.Contains(string(yml), want) {
+		t.Errorf("expected the inline oneOf schema in the response, got:\n%s", yml)
+	}
+
+	if !strings.Contains(string(yml), "Redirect:") || strings.Contains(string(yml), "Unused:") {
+		t.Errorf("expected only the subschemas to be kept by pruning, got:\n%s", yml)
+	}
+
+	o.Paths[0].Responses[0].Content[0].InlineSchema.OneOf[1].Ref = "#/components/schemas/Missing"
+
+	if err := o.Validate(); err == nil || !strings.Contains(err.Error(), "Missing") {
+		t.Errorf("expected an error for the undefined subschema, got %v", err)
+	}
+}
+
+func TestUnitMakeRequestBodyMapInlineRequired(t *testing.T) {
+	t.Parallel()
+
+	reqBody := RequestBody{
+		Description: "New user",
+		Required:    true,
+		Content: ContentTypes{ContentType{Name: "application/json", InlineSchema: &Schema{
+			Type:     "object",
+			Required: []string{"email", "name"},
+			Properties: Properties{
+				Property{Name: "email", Schema: Schema{Type: "string"}},
+				Property{Name: "name", Schema: Schema{Type: "string"}},
+				Property{Name: "nickname", Schema: Schema{Type: "string"}},
+			},
+		}}},
+	}
+
+	yml, err := yaml.Marshal(makeRequestBodyMap(&reqBody, "3.0.3"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "content:\n    application/json:\n        schema:\n            properties:\n" +
+		"                email:\n                    type: string\n" +
+		"                name:\n                    type: string\n" +
+		"                nickname:\n                    type: string\n" +
+		"            required:\n                - email\n                - name\n            type: object\n" +
+		"description: New user\nrequired: true\n"
+	if string(yml) != want {
+		t.Errorf("got %q, but want %q", yml, want)
+	}
+}
+
+func TestUnitMakeContentSchemaMapFormEncoding(t *testing.T) {
+	t.Parallel()
+
+	explode := true
+	content := ContentTypes{ContentType{
+		Name: "application/x-www-form-urlencoded",
+		InlineSchema: &Schema{Type: "object", Properties: Properties{
+			Property{Name: "tags", Schema: Schema{Type: "array", Items: &Schema{Type: "string"}}},
+			Property{Name: "redirect", Schema: Schema{Type: "string"}},
+		}},
+		Encoding: map[string]Encoding{
+			"tags":     {Style: "form", Explode: &explode},
+			"redirect": {AllowReserved: true},
+		},
+	}}
+
+	got, _ := makeContentSchemaMap(content, "3.0.3")["application/x-www-form-urlencoded"].(map[string]interface{})
+
+	want := map[string]interface{}{
+		"tags":     map[string]interface{}{keyStyle: "form", keyExplode: true},
+		"redirect": map[string]interface{}{keyAllowReserved: true},
+	}
+	if !reflect.DeepEqual(got[keyEncoding], want) {
+		t.Errorf("got %v, but want %v", got[keyEncoding], want)
+	}
+}
+
+func TestUnitMakeContentSchemaMapEncoding(t *testing.T) {
+	t.Parallel()
+
+	explode := false
+	content := ContentTypes{ContentType{
+		Name:   "multipart/form-data",
+		Schema: "#/components/schemas/ResumeUpload",
+		Encoding: map[string]Encoding{
+			"metadata": {ContentType: "application/json"},
+			"file": {
+				ContentType: "application/pdf",
+				Headers: Headers{"X-Rate-Limit": Header{
+					Description: "Allowed uploads per hour",
+					Schema:      &Schema{Type: "integer"},
+				}},
+				Style:   "form",
+				Explode: &explode,
+			},
+		},
+	}}
+
+	yml, err := yaml.Marshal(makeContentSchemaMap(content, "3.0.3"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `multipart/form-data:
+    encoding:
+        file:
+            contentType: application/pdf
+            explode: false
+            headers:
+                X-Rate-Limit:
+                    description: Allowed uploads per hour
+                    schema:
+                        type: integer
+            style: form
+        metadata:
+            contentType: application/json
+    schema:
+        $ref: '#/components/schemas/ResumeUpload'
+`
+	if string(yml) != want {
+		t.Errorf("got:\n%s\nbut want:\n%s", yml, want)
+	}
+}
+
+func TestUnitMakeContentSchemaMapExamples(t *testing.T) {
+	t.Parallel()
+
+	content := ContentTypes{ContentType{
+		Name:     "application/json",
+		Schema:   "#/components/schemas/User",
+		Examples: Examples{"external": Example{Summary: "Large", ExternalValue: "https://example.com/users.json"}},
+	}}
+
+	yml, err := yaml.Marshal(makeContentSchemaMap(content, "3.0.3"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "examples:\n        external:\n            summary: Large\n            externalValue: https://example.com/users.json\n"
+	if !strings.Contains(string(yml), want) {
+		t.Errorf("unexpected examples output:\n%s", yml)
+	}
+}
+
+func TestUnitMakeResponsesMapCodeRanges(t *testing.T) {
+	t.Parallel()
+
+	responses := Responses{
+		Response{Code: 201, Description: "Created"},
+		Response{CodeRange: "2XX", Description: "Success"},
+		Response{CodeRange: ResponseCodeDefault, Description: "Error"},
+	}
+
+	got := makeResponsesMap(&responses, "3.0.3")
+
+	for _, key := range []string{"201", "2XX", ResponseCodeDefault} {
+		if _, ok := got[key]; !ok {
+			t.Errorf("expected response under %q key, got %v", key, got)
+		}
+	}
+}
+
+func TestUnitBuildDocsLogger(t *testing.T) {
+	t.Parallel()
+
+	var msgs []string
+
+	o := New()
+	o.Paths = Paths{
+		Path{Route: "/users", HTTPMethod: "GET"},
+		Path{Route: "/users/", HTTPMethod: "POST"},
+	}
+
+	_, err := o.BuildDocsBytes(ConfigBuilder{
+		TrailingSlash: TrailingSlashStrip,
+		Logger: func(msg string) {
+			msgs = append(msgs, msg)
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := strings.Join(msgs, "\n")
+	for _, want := range []string{"validation passed", "emitting 1 routes", "WARNING: routes"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected logs to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestUnitMakeSchemaMapPatternProperties(t *testing.T) {
+	t.Parallel()
+
+	s := Schema{
+		Name:              "Translations",
+		Type:              "object",
+		PatternProperties: map[string]*Schema{"^[a-z]{2}(-[A-Z]{2})?$": {Type: "string"}},
+	}
+
+	if _, ok := makeSchemaMap(&s, "3.0.3")[keyPatternProperties]; ok {
+		t.Error("expected patternProperties to be omitted for 3.0")
+	}
+
+	got, _ := makeSchemaMap(&s, "3.1.0")[keyPatternProperties].(map[string]interface{})
+
+	locale, _ := got["^[a-z]{2}(-[A-Z]{2})?$"].(map[string]interface{})
+	if locale[keyType] != "string" {
+		t.Errorf("unexpected patternProperties: %v", got)
+	}
+
+	s.PatternProperties["^x-"] = nil
+
+	got, _ = makeSchemaMap(&s, "3.1.0")[keyPatternProperties].(map[string]interface{})
+	if _, ok := got["^x-"]; ok || len(got) != 1 {
+		t.Errorf("expected the nil pattern property to be skipped, got %v", got)
+	}
+
+	s.PatternProperties = map[string]*Schema{"^x-": nil}
+
+	if _, ok := makeSchemaMap(&s, "3.1.0")[keyPatternProperties]; ok {
+		t.Error("expected patternProperties to be omitted with only nil schemas")
+	}
+}
+
+func TestUnitMakeSchemaMapNullable(t *testing.T) {
+	t.Parallel()
+
+	s := Schema{Type: "string", Nullable: true}
+
+	got30 := makeSchemaMap(&s, "3.0.3")
+	if got30[keyType] != "string" || got30[keyNullable] != true {
+		t.Errorf("expected a nullable string for 3.0, got %v", got30)
+	}
+
+	got31 := makeSchemaMap(&s, "3.1.0")
+	if _, ok := got31[keyNullable]; ok || !reflect.DeepEqual(got31[keyType], []string{"string", typeNull}) {
+		t.Errorf("expected a type array with null for 3.1, got %v", got31)
+	}
+
+	o := New()
+	o.OASVersion = "3.1.0"
+	o.Components = Components{Component{Schemas: Schemas{Schema{Name: "Any", Nullable: true}}}}
+
+	if err := o.Validate(); err == nil {
+		t.Error("expected an error for a nullable schema without a type")
+	}
+}
+
+func TestUnitMakeSchemaMapExamples(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		schema      Schema
+		ver         OASVersion
+		wantExample interface{}
+		wantList    []interface{}
+	}{
+		{
+			name:        "single example for 3.0",
+			schema:      Schema{Type: "string", Example: "john"},
+			ver:         "3.0.3",
+			wantExample: "john",
+		},
+		{
+			name:        "first of examples for 3.0",
+			schema:      Schema{Type: "string", Examples: []interface{}{"jane", "john"}},
+			ver:         "3.0.3",
+			wantExample: "jane",
+		},
+		{
+			name:        "example preferred for 3.0",
+			schema:      Schema{Type: "string", Example: "john", Examples: []interface{}{"jane"}},
+			ver:         "3.0.3",
+			wantExample: "john",
+		},
+		{
+			name:     "single example as array for 3.1",
+			schema:   Schema{Type: "string", Example: "john"},
+			ver:      "3.1.0",
+			wantList: []interface{}{"john"},
+		},
+		{
+			name:     "both merged for 3.1",
+			schema:   Schema{Type: "string", Example: "john", Examples: []interface{}{"jane"}},
+			ver:      "3.1.0",
+			wantList: []interface{}{"john", "jane"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := makeSchemaMap(&tt.schema, tt.ver)
+
+			if got[keyExample] != tt.wantExample {
+				t.Errorf("example = %v, want %v", got[keyExample], tt.wantExample)
+			}
+
+			list, _ := got[keyExamples].([]interface{})
+			if !reflect.DeepEqual(list, tt.wantList) {
+				t.Errorf("examples = %v, want %v", list, tt.wantList)
+			}
+		})
+	}
+}
+
+func TestUnitMakeSchemaMapNestedProperties(t *testing.T) {
+	t.Parallel()
+
+	s := Schema{
+		Name:     "Order",
+		Type:     "object",
+		Required: []string{"id"},
+		Properties: Properties{
+			Property{Name: "id", Schema: Schema{Type: "integer", Format: "int64"}},
+			Property{Name: "shipping", Schema: Schema{
+				Type: "object",
+				Properties: Properties{
+					Property{Name: "address", Schema: Schema{Ref: "#/components/schemas/Address"}},
+				},
+			}},
+			Property{Name: "items", Schema: Schema{
+				Type:  "array",
+				Items: &Schema{Ref: "#/components/schemas/OrderItem"},
+			}},
+		},
+	}
+
+	yml, err := yaml.Marshal(makeSchemaMap(&s, "3.0.3"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []string{
+		"required:\n    - id\n",
+		"shipping:\n        properties:\n            address:\n                $ref: '#/components/schemas/Address'\n",
+		"items:\n        items:\n            $ref: '#/components/schemas/OrderItem'\n        type: array\n",
+	} {
+		if !strings.Contains(string(yml), want) {
+			t.Errorf("expected output to contain:\n%s\ngot:\n%s", want, yml)
+		}
+	}
+
+	refs := s.schemaRefs()
+	if len(refs) != 2 {
+		t.Errorf("expected refs of nested properties and items, got %v", refs)
+	}
+}
+
+func TestUnitBuildDocsDefaultResponses(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.DefaultResponses = Responses{
+		Response{CodeRange: "5XX", Description: "Server error"},
+	}
+	o.Paths = Paths{
+		Path{Route: "/users", HTTPMethod: "GET", Responses: Responses{Response{Code: 200, Description: "OK"}}},
+	}
+
+	got, _ := o.transformToHybridOAS(ConfigBuilder{}).Paths["/users"]["get"].(map[string]interface{})
+	responses, _ := got[keyResponses].(map[string]interface{})
+
+	for _, key := range []string{"200", "5XX"} {
+		if _, ok := responses[key]; !ok {
+			t.Errorf("expected response under %q key, got %v", key, responses)
+		}
+	}
+
+	if len(o.Paths[0].Responses) != 1 {
+		t.Errorf("expected the path responses to be left untouched, got %+v", o.Paths[0].Responses)
+	}
+}
+
+func TestUnitBuildDocsDeprecated(t *testing.T) {
+	t.Parallel()
+
+	notDeprecated := false
+
+	o := New()
+	o.Deprecated = true
+	o.Paths = Paths{
+		Path{Route: "/users", HTTPMethod: "GET"},
+		Path{Route: "/users", HTTPMethod: "POST", Deprecated: &notDeprecated},
+	}
+
+	got := o.transformToHybridOAS(ConfigBuilder{}).Paths["/users"]
+
+	get, _ := got["get"].(map[string]interface{})
+	if get[keyDeprecated] != true {
+		t.Errorf("expected operation to inherit deprecation, got %v", get)
+	}
+
+	post, _ := got["post"].(map[string]interface{})
+	if _, ok := post[keyDeprecated]; ok {
+		t.Errorf("expected operation override to take precedence, got %v", post)
+	}
+
+	if o.Paths[0].Deprecated != nil {
+		t.Error("expected the paths to be left untouched")
+	}
+}
+
+func TestUnitBuildDocsInfoExtensions(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Info.Title = "Branded"
+	o.Info.Extensions = map[string]interface{}{
+		"x-logo": map[string]string{"url": "https://example.com/logo.png"},
+	}
+
+	yml, err := o.BuildDocsBytes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "    version: \"\"\n    x-logo:\n        url: https://example.com/logo.png\n"
+	if !strings.Contains(string(yml), want) {
+		t.Errorf("expected x-logo under info, got:\n%s", yml)
+	}
+}
+
+func TestUnitMakeSecurityMap(t *testing.T) {
+	t.Parallel()
+
+	se := SecurityEntities{
+		UseSecurity("petstore_auth", "write:pets", "read:pets"),
+		UseSecurity("api_key"),
+	}
+
+	yml, err := yaml.Marshal(makeSecurityMap(&se))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "- petstore_auth:\n    - write:pets\n    - read:pets\n- api_key: []\n"
+	if string(yml) != want {
+		t.Errorf("got %q, but want %q", yml, want)
+	}
+}
+
+func TestUnitMakeComponentsMapPathItems(t *testing.T) {
+	t.Parallel()
+
+	components := Components{Component{
+		PathItems: ComponentPathItems{"Notification": ComponentPathItem{
+			Summary: "Notification delivery",
+			Operations: Paths{Path{
+				HTTPMethod:  "POST",
+				OperationID: "notify",
+				Responses:   Responses{Response{Code: 204, Description: "Received"}},
+			}},
+		}},
+	}}
+
+	if _, ok := makeComponentsMap(&components, "3.0.3")[keyPathItems]; ok {
+		t.Error("expected pathItems to be omitted for 3.0")
+	}
+
+	pathItems, _ := makeComponentsMap(&components, "3.1.0")[keyPathItems].(map[string]interface{})
+
+	notification, _ := pathItems["Notification"].(methodsMap)
+	if notification[keySummary] != "Notification delivery" {
+		t.Errorf("unexpected path item: %v", notification)
+	}
+
+	post, _ := notification["post"].(map[string]interface{})
+	if post[keyOperationID] != "notify" {
+		t.Errorf("expected the operation keyed by its method, got %v", notification)
+	}
+
+	if ref := ComponentPathItemRef("Notification"); ref != "#/components/pathItems/Notification" {
+		t.Errorf("unexpected ref %q", ref)
+	}
+}
+
+func TestUnitBuildDocsDeduplicatesTags(t *testing.T) {
+	t.Parallel()
+
+	var msgs []string
+
+	o := New()
+	o.Tags.SetTag("users", "User operations", ExternalDocs{})
+	o.Tags.SetTag("orders", "Order operations", ExternalDocs{})
+	o.Tags.SetTag("users", "Duplicate", ExternalDocs{})
+	o.Tags.SetTag("orders", "Order operations", ExternalDocs{})
+	o.Paths = Paths{Path{Route: "/users", HTTPMethod: "GET", Tags: []string{"users", "users"}}}
+
+	ho := o.transformToHybridOAS(ConfigBuilder{Logger: func(msg string) { msgs = append(msgs, msg) }})
+
+	if len(ho.Tags) != 2 || ho.Tags[0].Description != "User operations" || ho.Tags[1].Name != "orders" {
+		t.Errorf("unexpected tags: %+v", ho.Tags)
+	}
+
+	if len(msgs) == 0 || !strings.Contains(strings.Join(msgs, "\n"), `tag "users" is defined multiple times`) {
+		t.Errorf("expected a warning for the conflicting duplicate, got %v", msgs)
+	}
+
+	if strings.Contains(strings.Join(msgs, "\n"), `tag "orders"`) {
+		t.Errorf("expected no warning for the identical duplicate, got %v", msgs)
+	}
+
+	get, _ := ho.Paths["/users"]["get"].(map[string]interface{})
+	if tags, _ := get[keyTags].([]string); len(tags) != 1 {
+		t.Errorf("expected operation tags to be deduplicated, got %v", get[keyTags])
+	}
+}
+
+func TestUnitTransformToHybridOASJSONSchemaDialect(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		ver     OASVersion
+		dialect string
+		want    string
+	}{
+		{name: "omitted for 3.0", ver: "3.0.3", dialect: "https://example.com/dialect", want: ""},
+		{name: "default for 3.1", ver: "3.1.0", want: "https://spec.openapis.org/oas/3.1/dialect/base"},
+		{name: "custom for 3.1", ver: "3.1.0", dialect: "https://example.com/dialect", want: "https://example.com/dialect"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			o := OAS{OASVersion: tt.ver, JSONSchemaDialect: tt.dialect}
+
+			if got := o.transformToHybridOAS(ConfigBuilder{}).JSONSchemaDialect; got != tt.want {
+				t.Errorf("got %q, but want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnitMakeResponsesMapRefsAndHeaders(t *testing.T) {
+	t.Parallel()
+
+	responses := Responses{
+		Response{Code: 404, Ref: ComponentResponseRef("NotFound"), Description: "No such user"},
+		Response{
+			Code:        201,
+			Description: "Created",
+			Headers:     Headers{"Location": Header{Description: "URL of the user", Schema: &Schema{Type: "string"}}},
+		},
+	}
+
+	yml, err := yaml.Marshal(makeResponsesMap(&responses, "3.0.3"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `"201":
+    content: {}
+    description: Created
+    headers:
+        Location:
+            description: URL of the user
+            schema:
+                type: string
+"404":
+    $ref: '#/components/responses/NotFound'
+`
+	if string(yml) != want {
+		t.Errorf("got:\n%s\nbut want:\n%s", yml, want)
+	}
+
+	got := makeResponsesMap(&responses, "3.1.0")
+	if notFound, _ := got["404"].(map[string]interface{}); notFound[keyDescription] != "No such user" {
+		t.Errorf("expected the description next to the ref for 3.1, got %v", notFound)
+	}
+
+	components := Components{Component{Responses: map[string]Response{"NotFound": {Description: "Not Found"}}}}
+	if _, ok := makeComponentsMap(&components, "3.0.3")[keyResponses]; !ok {
+		t.Error("expected the component responses to be emitted")
+	}
+}
+
+func TestUnitBuildDocsDescriptionFile(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Info.Description = "Overridden"
+	o.Info.DescriptionFile = "./testdata/description.md"
+
+	yml, err := o.BuildDocsBytes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "    description: |\n        # Users API\n\n        Manages **users**.\n"
+	if !strings.Contains(string(yml), want) {
+		t.Errorf("expected the description to be read from the file, got:\n%s", yml)
+	}
+
+	if o.Info.Description != "Overridden" {
+		t.Errorf("expected the OAS description to be left untouched, got %q", o.Info.Description)
+	}
+
+	missing := New()
+	missing.Info.DescriptionFile = "./testdata/missing.md"
+
+	if _, err := missing.BuildDocsBytes(); !errors.Is(err, ErrRead) {
+		t.Errorf("expected a read error, got %v", err)
+	}
+}
+
+func TestUnitMakeOperationMapRequestAndResponseExamples(t *testing.T) {
+	t.Parallel()
+
+	path := Path{
+		Route:      "/users",
+		HTTPMethod: "POST",
+		RequestBody: RequestBody{Content: ContentTypes{ContentType{
+			Name:     "application/json",
+			Schema:   "#/components/schemas/User",
+			Examples: Examples{"new": Example{Value: map[string]string{"name": "john"}}},
+		}}},
+		Responses: Responses{Response{Code: 201, Content: ContentTypes{ContentType{
+			Name:     "application/json",
+			Schema:   "#/components/schemas/User",
+			Examples: Examples{"created": Example{Value: map[string]interface{}{"id": 1, "name": "john"}}},
+		}}}},
+	}
+
+	got := makeOperationMap(&path, "3.0.3")
+
+	reqContent, _ := got[keyRequestBody].(map[string]interface{})[keyContent].(map[string]interface{})
+	reqJSON, _ := reqContent["application/json"].(map[string]interface{})
+	reqExamples, _ := reqJSON[keyExamples].(Examples)
+
+	created, _ := got[keyResponses].(map[string]interface{})["201"].(map[string]interface{})
+	respContent, _ := created[keyContent].(map[string]interface{})
+	respJSON, _ := respContent["application/json"].(map[string]interface{})
+	respExamples, _ := respJSON[keyExamples].(Examples)
+
+	if _, ok := reqExamples["new"]; !ok || len(reqExamples) != 1 {
+		t.Errorf("expected only the request example in the request body, got %v", reqExamples)
+	}
+
+	if _, ok := respExamples["created"]; !ok || len(respExamples) != 1 {
+		t.Errorf("expected only the response example in the response, got %v", respExamples)
+	}
+}
+
+func TestUnitMakeComponentSecuritySchemesMapOpenIDConnect(t *testing.T) {
+	t.Parallel()
+
+	secSchemes := SecuritySchemes{SecurityScheme{
+		Name:             "sso",
+		Type:             "openIdConnect",
+		OpenIDConnectURL: "https://sso.example.com/.well-known/openid-configuration",
+	}}
+
+	yml, err := yaml.Marshal(makeComponentSecuritySchemesMap(&secSchemes))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "sso:\n    openIdConnectUrl: https://sso.example.com/.well-known/openid-configuration\n" +
+		"    type: openIdConnect\n"
+	if string(yml) != want {
+		t.Errorf("got %q, but want %q", yml, want)
+	}
+}
+
+func TestUnitBuildDocsComponentsOnly(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Info.Title = "Shared schemas"
+	o.Paths = Paths{Path{Route: "/users", HTTPMethod: "GET"}}
+	o.Components = Components{Component{Schemas: Schemas{
+		Schema{Name: "User", Type: "object", Properties: Properties{
+			Property{Name: "address", Schema: Schema{Ref: "#/components/schemas/Address"}},
+		}},
+		Schema{Name: "Address", Type: "object"},
+	}}}
+
+	yml, err := o.BuildDocsBytes(ConfigBuilder{ComponentsOnly: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "components:\n    schemas:\n        Address:\n            type: object\n" +
+		"        User:\n            properties:\n                address:\n" +
+		"                    $ref: '#/components/schemas/Address'\n            type: object\n"
+	if string(yml) != want {
+		t.Errorf("got %q, but want %q", yml, want)
+	}
+}
+
+func TestUnitBuildDocsConflictingOutputOptions(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	outPath := filepath.Join(t.TempDir(), "openapi.yaml")
+
+	err := o.BuildDocs(ConfigBuilder{CustomPath: outPath, Split: true, ComponentsOnly: true})
+	if !errors.Is(err, ErrValidation) {
+		t.Errorf("expected a validation error for split components only output, got %v", err)
+	}
+
+	if _, err := os.Stat(outPath); !os.IsNotExist(err) {
+		t.Error("expected no output file to be written")
+	}
+
+	if err := o.BuildDocs(ConfigBuilder{CustomPath: stdoutPath, Split: true}); !errors.Is(err, ErrValidation) {
+		t.Errorf("expected a validation error for split output to the standard output, got %v", err)
+	}
+
+	if _, err := os.Stat(stdoutPath); !os.IsNotExist(err) {
+		t.Error("expected no file named after the standard output to be written")
+	}
+}
+
+func TestUnitMakeSchemaMapEnumStyle(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		enum []string
+		want string
+	}{
+		{name: "short enum in flow style", enum: []string{"EUR", "USD"}, want: "enum: [EUR, USD]\n"},
+		{
+			name: "long enum in block style",
+			enum: []string{"CHF", "EUR", "GBP", "JPY", "USD"},
+			want: "enum:\n    - CHF\n    - EUR\n    - GBP\n    - JPY\n    - USD\n",
+		},
+		{name: "values are quoted when needed", enum: []string{"yes", "1"}, want: "enum: [\"yes\", \"1\"]\n"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			yml, err := yaml.Marshal(makeSchemaMap(&Schema{Enum: tt.enum}, "3.0.3"))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !strings.Contains(string(yml), tt.want) {
+				t.Errorf("expected %q in:\n%s", tt.want, yml)
+			}
+		})
+	}
+}
+
+func TestUnitLineEndingNormalize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		ending  LineEnding
+		content string
+		want    string
+	}{
+		{name: "LF by default", ending: LineEndingLF, content: "a: 1\r\nb: 2\n", want: "a: 1\nb: 2\n"},
+		{name: "CRLF", ending: LineEndingCRLF, content: "a: 1\nb: 2\n", want: "a: 1\r\nb: 2\r\n"},
+		{name: "CRLF is not doubled", ending: LineEndingCRLF, content: "a: 1\r\nb: 2\n", want: "a: 1\r\nb: 2\r\n"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := string(tt.ending.normalize([]byte(tt.content))); got != tt.want {
+				t.Errorf("got %q, but want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnitBuildDocsLineEnding(t *testing.T) {
+	t.Parallel()
+
+	outPath := t.TempDir() + "/openapi.yaml"
+	o := New()
+
+	err := o.BuildDocs(ConfigBuilder{CustomPath: outPath, LineEnding: LineEndingCRLF})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content := readFileForTest(t, outPath)
+	if !strings.HasPrefix(content, "openapi: \"\"\r\n") || strings.Count(content, "\n") != strings.Count(content, "\r\n") {
+		t.Errorf("expected CRLF line endings only, got %q", content)
+	}
+}
+
+//nolint:paralleltest //replaces os.Stdout.
+func TestUnitBuildDocsToStdout(t *testing.T) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed creating pipe: %v", err)
+	}
+
+	stdout := os.Stdout
+	os.Stdout = writer
+
+	o := New()
+	o.OASVersion = "3.0.3"
+	err = o.BuildDocs(ConfigBuilder{CustomPath: "-"})
+
+	os.Stdout = stdout
+	writer.Close()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("failed reading stdout: %v", err)
+	}
+
+	if !strings.HasPrefix(string(out), "openapi: 3.0.3\n") {
+		t.Errorf("expected the docs on stdout, got %q", out)
+	}
+
+	if _, err := os.Stat("-"); !os.IsNotExist(err) {
+		t.Error("expected no file named - to be created")
+	}
+}
+
+func TestUnitBuildDocsForceBlockStyle(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Components = Components{Component{Schemas: Schemas{
+		Schema{Name: "Currency", Type: "string", Enum: []string{"EUR", "USD"}},
+	}}}
+
+	yml, err := o.BuildDocsBytes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(string(yml), "enum: [EUR, USD]") {
+		t.Errorf("expected the short enum in flow style by default, got:\n%s", yml)
+	}
+
+	yml, err = o.BuildDocsBytes(ConfigBuilder{ForceBlockStyle: true, DescriptionsAsComments: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(string(yml), "enum:\n                - EUR\n                - USD\n") {
+		t.Errorf("expected the short enum in block style, got:\n%s", yml)
+	}
+
+	if !strings.Contains(string(yml), "paths: {}") {
+		t.Errorf("expected empty mappings to be kept in flow style, got:\n%s", yml)
+	}
+}
+
+func TestUnitTransformToHybridOASLicenseIdentifier(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Info.License = License{Name: "Apache 2.0", Identifier: "Apache-2.0"}
+
+	o.OASVersion = "3.0.3"
+	if got := o.transformToHybridOAS(ConfigBuilder{}).Info.License.Identifier; got != emptyStr {
+		t.Errorf("expected the license identifier to be omitted for 3.0, got %q", got)
+	}
+
+	o.OASVersion = "3.1.0"
+
+	yml, err := o.BuildDocsBytes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "    license:\n        name: Apache 2.0\n        identifier: Apache-2.0\n"
+	if !strings.Contains(string(yml), want) {
+		t.Errorf("expected %q in:\n%s", want, yml)
+	}
+}
+
+func TestUnitBuildDocsHeaderComment(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	cb := ConfigBuilder{HeaderComment: "Code generated by go-oas-docs; DO NOT EDIT.\n\nSource: api/docs.go"}
+
+	yml, err := o.BuildDocsBytes(cb)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "# Code generated by go-oas-docs; DO NOT EDIT.\n#\n# Source: api/docs.go\nopenapi: \"\"\n"
+	if !strings.HasPrefix(string(yml), want) {
+		t.Errorf("expected the output to start with %q, got:\n%s", want, yml)
+	}
+
+	jsn, err := o.buildDocsInFormat(cb, formatJSON)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Contains(string(jsn), "DO NOT EDIT") {
+		t.Errorf("expected no header comment in the JSON output, got:\n%s", jsn)
+	}
+}
+
+func TestUnitBuildDocsDefaultMediaType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		defaultMediaType string
+		want             string
+	}{
+		{name: "application/json by default", want: "application/json"},
+		{name: "configured", defaultMediaType: "application/vnd.api+json", want: "application/vnd.api+json"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			o := New()
+			o.DefaultMediaType = tt.defaultMediaType
+			o.Components = Components{Component{Schemas: Schemas{Schema{Name: "User"}}}}
+			o.Paths = Paths{Path{
+				Route: "/users", HTTPMethod: "POST",
+				RequestBody: RequestBody{Content: ContentTypes{ContentType{Schema: "#/components/schemas/User"}}},
+				Responses: Responses{Response{Code: 201, Content: ContentTypes{
+					ContentType{InlineSchema: &Schema{Type: "object"}},
+					ContentType{Name: "application/xml", Schema: "#/components/schemas/User"},
+				}}},
+			}}
+
+			if err := o.Validate(); err != nil {
+				t.Fatalf("expected unnamed content with a schema to pass the validation, got %v", err)
+			}
+
+			yml, err := o.BuildDocsBytes()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var out struct {
+				Paths map[string]map[string]struct {
+					RequestBody struct {
+						Content map[string]interface{} `yaml:"content"`
+					} `yaml:"requestBody"`
+					Responses map[string]struct {
+						Content map[string]interface{} `yaml:"content"`
+					} `yaml:"responses"`
+				} `yaml:"paths"`
+			}
+
+			if err := yaml.Unmarshal(yml, &out); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			op := out.Paths["/users"]["post"]
+			if _, ok := op.RequestBody.Content[tt.want]; !ok {
+				t.Errorf("expected request body media type %q, got %v", tt.want, op.RequestBody.Content)
+			}
+
+			if _, ok := op.Responses["201"].Content[tt.want]; !ok {
+				t.Errorf("expected response media type %q, got %v", tt.want, op.Responses["201"].Content)
+			}
+
+			if name := o.Paths[0].RequestBody.Content[0].Name; name != "" {
+				t.Errorf("expected the OAS to be left untouched, got media type %q", name)
+			}
+
+			if _, ok := op.Responses["201"].Content["application/xml"]; !ok {
+				t.Errorf("expected the explicit media type to be kept, got %v", op.Responses["201"].Content)
+			}
+		})
+	}
+}
+
+func TestUnitBuildDocsOmitsEmptyComponents(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		components Components
+		want       []string
+		notWant    []string
+	}{
+		{name: "no components", notWant: []string{"components:"}},
+		{name: "empty component", components: Components{Component{}}, notWant: []string{"components:"}},
+		{
+			name: "only security schemes",
+			components: Components{Component{SecuritySchemes: SecuritySchemes{
+				SecurityScheme{Name: "api_key", Type: "apiKey", In: "header"},
+			}}},
+			want:    []string{"components:", "securitySchemes:"},
+			notWant: []string{"schemas:"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			o := New()
+			o.Components = tt.components
+
+			yml, err := o.BuildDocsBytes()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for _, want := range tt.want {
+				if !strings.Contains(string(yml), want) {
+					t.Errorf("expected output to contain %q, got:\n%s", want, yml)
+				}
+			}
+
+			for _, notWant := range tt.notWant {
+				if strings.Contains(string(yml), notWant) {
+					t.Errorf("expected output not to contain %q, got:\n%s", notWant, yml)
+				}
+			}
+		})
+	}
+}
+
+func TestUnitMakePropertiesMapOrder(t *testing.T) {
+	t.Parallel()
+
+	props := Properties{
+		Property{Name: "type", Schema: Schema{Type: "string"}},
+		Property{Name: "id", Schema: Schema{Type: "integer"}},
+		Property{Name: "amount", Schema: Schema{Type: "number"}},
+	}
+
+	yml, err := yaml.Marshal(makePropertiesMap(&props, "3.0.3"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "type:\n    type: string\nid:\n    type: integer\namount:\n    type: number\n"
+	if string(yml) != want {
+		t.Errorf("got:\n%s\nbut want the declared order:\n%s", yml, want)
+	}
+}
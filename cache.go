@@ -0,0 +1,128 @@
+package docs
+
+import (
+	"reflect"
+	"sync"
+)
+
+// cacheMuInit guards the lazy creation of the cache mutex of each OAS.
+var cacheMuInit sync.Mutex //nolint:gochecknoglobals //guards lazy init only.
+
+// docsCache holds the last output of BuildDocsBytes, along with the config and the OAS used to produce it.
+type docsCache struct {
+	valid    bool
+	owner    *OAS // Value copies of the OAS share the cache, but do not own it.
+	snapshot *OAS
+	routes   map[string]uintptr
+	conf     ConfigBuilder
+	yml      []byte
+}
+
+// Invalidate forces the next BuildDocsBytes call to rebuild the output.
+//
+// Modifications of the OAS are detected by BuildDocsBytes on its own, so this is required only
+// to release the cached output, or after replacing a RouteFn by another closure of the same function.
+func (o *OAS) Invalidate() {
+	if o == nil {
+		return
+	}
+
+	o.cache = docsCache{}
+}
+
+// BuildDocsBytes marshals the OAS struct to YAML and returns it, without writing to any output file.
+//
+// The output is cached and reused by the following calls with an equal config, as long as the OAS is deeply equal
+// to the one the output was built from. Copies of the OAS never reuse the output cached by the original.
+// Configs with a Logger or ExcludeWhere set are never considered to be equal, so these are always rebuilt,
+// and so are the docs reading the info description from a file or stamping the build info.
+// Concurrent calls are safe, as long as the OAS is not modified meanwhile. Every call returns its own copy.
+func (o *OAS) BuildDocsBytes(conf ...ConfigBuilder) ([]byte, error) {
+	cb := getConfigFromFirstElement(conf)
+
+	mu := o.cacheLock()
+	mu.Lock()
+	defer mu.Unlock()
+
+	if o.isCached(cb) {
+		return append([]byte(nil), o.cache.yml...), nil
+	}
+
+	yml, err := o.buildDocsBytes(cb)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.isCacheable(cb) {
+		o.cache = docsCache{
+			valid:    true,
+			owner:    o,
+			snapshot: o.snapshot(),
+			routes:   o.routePointers(),
+			conf:     cb,
+			yml:      append([]byte(nil), yml...),
+		}
+	}
+
+	return yml, nil
+}
+
+// buildDocsBytes marshals the OAS struct to YAML, bypassing the cache.
+func (o *OAS) buildDocsBytes(cb ConfigBuilder) ([]byte, error) {
+	build, err := o.prepareForBuild(cb)
+	if err != nil {
+		return nil, err
+	}
+
+	yml, err := marshalToYAML(build, cb)
+	if err != nil {
+		return nil, newBuildError(ErrMarshal, err)
+	}
+
+	return yml, nil
+}
+
+// isCached checks if the cached output was built by this very OAS, with an equal config and from equal fields.
+func (o *OAS) isCached(cb ConfigBuilder) bool {
+	return o.cache.valid && o.cache.owner == o &&
+		reflect.DeepEqual(o.cache.conf, cb) &&
+		reflect.DeepEqual(o.cache.routes, o.routePointers()) &&
+		reflect.DeepEqual(o.cache.snapshot, o.snapshot())
+}
+
+// snapshot returns a deep copy of the exported fields, without the registered routes,
+// as functions are never deeply equal. These are compared by routePointers instead.
+func (o *OAS) snapshot() *OAS {
+	snap := o.exportedCopy(nil)
+	snap.RegisteredRoutes = nil
+
+	return snap
+}
+
+// routePointers returns the code pointers of the registered routes, keyed by their names.
+func (o *OAS) routePointers() map[string]uintptr {
+	pointers := make(map[string]uintptr, len(o.RegisteredRoutes))
+	for name, fn := range o.RegisteredRoutes {
+		pointers[name] = reflect.ValueOf(fn).Pointer()
+	}
+
+	return pointers
+}
+
+// cacheLock returns the mutex guarding the cache, creating it on the first call.
+func (o *OAS) cacheLock() *sync.Mutex {
+	cacheMuInit.Lock()
+	defer cacheMuInit.Unlock()
+
+	if o.cacheMu == nil {
+		o.cacheMu = &sync.Mutex{}
+	}
+
+	return o.cacheMu
+}
+
+// isCacheable checks if the output depends only on the OAS and the config, and not on e.g. files or time,
+// which the cache cannot track.
+func (o *OAS) isCacheable(cb ConfigBuilder) bool {
+	return !cb.StampBuildInfo && isStrEmpty(o.Info.DescriptionFile)
+}
@@ -0,0 +1,172 @@
+package docs
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestUnitBuildDocsBytesCache(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Info.Title = "Cache Testing"
+
+	first, err := o.BuildDocsBytes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	snapshot := o.cache.snapshot
+
+	cached, err := o.BuildDocsBytes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !bytes.Equal(first, cached) || o.cache.snapshot != snapshot {
+		t.Error("expected cached output to be reused")
+	}
+
+	o.Info.Title = "Mutated directly"
+
+	rebuilt, err := o.BuildDocsBytes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(string(rebuilt), "Mutated directly") {
+		t.Errorf("expected output to be rebuilt after a direct modification, got:\n%s", rebuilt)
+	}
+
+	o.GetInfo().Title = "Mutated through a pointer"
+
+	rebuilt, err = o.BuildDocsBytes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(string(rebuilt), "Mutated through a pointer") {
+		t.Errorf("expected output to be rebuilt after a modification through a pointer, got:\n%s", rebuilt)
+	}
+
+	o.SetOASVersion("3.1.0")
+
+	rebuilt, err = o.BuildDocsBytes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(string(rebuilt), "openapi: 3.1.0") {
+		t.Errorf("expected output to be rebuilt after a setter call, got:\n%s", rebuilt)
+	}
+
+	withConf, err := o.BuildDocsBytes(ConfigBuilder{OperationIDCase: OperationIDSnake})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if &withConf[0] == &rebuilt[0] {
+		t.Error("expected output to be rebuilt for a different config")
+	}
+}
+
+func TestUnitBuildDocsBytesCacheValueCopy(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Info.Title = "Original"
+
+	if _, err := o.BuildDocsBytes(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	copied := o
+	copied.Info.Title = "Copy"
+
+	yml, err := copied.BuildDocsBytes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(string(yml), "title: Copy") {
+		t.Errorf("expected the copy not to reuse the cache of the original, got:\n%s", yml)
+	}
+
+	if o.cache.owner != &o || copied.cache.owner != &copied {
+		t.Error("expected both the original and the copy to own their cached output")
+	}
+}
+
+func TestUnitBuildDocsBytesCacheCopies(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Info.Title = "Cache Testing"
+
+	first, err := o.BuildDocsBytes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := string(first)
+	first[0] = '#'
+
+	cached, err := o.BuildDocsBytes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(cached) != want {
+		t.Errorf("expected the cached output to be unaffected by modifying a returned one, got:\n%s", cached)
+	}
+}
+
+func TestUnitBuildDocsBytesCacheUncacheable(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Info.Title = "Cache Testing"
+
+	if _, err := o.BuildDocsBytes(ConfigBuilder{StampBuildInfo: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if o.cache.valid {
+		t.Error("expected the output stamping the build info not to be cached")
+	}
+
+	o.Info.DescriptionFile = "./testdata/description.md"
+
+	if _, err := o.BuildDocsBytes(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if o.cache.valid {
+		t.Error("expected the output reading the description file not to be cached")
+	}
+}
+
+func TestUnitBuildDocsBytesConcurrent(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Info.Title = "Cache Testing"
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if _, err := o.BuildDocsBytes(); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
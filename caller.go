@@ -21,8 +21,16 @@ func (o *OAS) Call(name string, params ...interface{}) (result []reflect.Value)
 	return result
 }
 
+// initCallStackForRoutes calls the RouteFn registered for the handler of every path.
+// Paths defined directly on the OAS (e.g. by RegisterCRUD or QuickSpec) have none, so these are skipped.
 func (o *OAS) initCallStackForRoutes() {
 	for oasPathIndex := range o.Paths {
-		o.Call(o.Paths[oasPathIndex].HandlerFuncName+routePostfix, oasPathIndex, o)
+		routeName := o.Paths[oasPathIndex].HandlerFuncName + routePostfix
+
+		if _, ok := o.RegisteredRoutes[routeName]; !ok {
+			continue
+		}
+
+		o.Call(routeName, oasPathIndex, o)
 	}
 }
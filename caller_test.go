@@ -74,6 +74,15 @@ func TestUnitInitCallStack(t *testing.T) {
 	o.initCallStackForRoutes()
 }
 
+func TestUnitInitCallStackUnregisteredRoutes(t *testing.T) {
+	t.Parallel()
+
+	o := prepForInitCallStack(t)
+	o.Paths = append(o.Paths, Path{Route: "/health", HTTPMethod: "GET"}, Path{HandlerFuncName: "unregistered"})
+
+	o.initCallStackForRoutes()
+}
+
 func prepForInitCallStack(t *testing.T) OAS {
 	t.Helper()
 
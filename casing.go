@@ -0,0 +1,96 @@
+package docs
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// OperationIDCase represents casing applied to operationIds while building.
+type OperationIDCase int
+
+// Supported OperationIDCase values.
+const (
+	OperationIDAsIs OperationIDCase = iota
+	OperationIDCamel
+	OperationIDSnake
+)
+
+// apply transforms the casing of the operationId. Empty operationIds are left empty.
+func (oic OperationIDCase) apply(operationID string) string {
+	switch oic {
+	case OperationIDCamel:
+		return toCamelCase(splitWords(operationID))
+	case OperationIDSnake:
+		return toSnakeCase(splitWords(operationID))
+	case OperationIDAsIs:
+		return operationID
+	default:
+		return operationID
+	}
+}
+
+func toCamelCase(words []string) string {
+	var sb strings.Builder
+
+	for i, word := range words {
+		word = strings.ToLower(word)
+		if i > 0 {
+			first, size := utf8.DecodeRuneInString(word)
+			word = string(unicode.ToUpper(first)) + word[size:]
+		}
+
+		sb.WriteString(word)
+	}
+
+	return sb.String()
+}
+
+func toSnakeCase(words []string) string {
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+
+	return strings.Join(words, "_")
+}
+
+// splitWords splits the identifier on separators and case boundaries, keeping acronyms together,
+// e.g. "getHTTPServer_status" results in ["get", "HTTP", "Server", "status"].
+func splitWords(s string) []string {
+	var (
+		words   []string
+		current []rune
+	)
+
+	runes := []rune(s)
+
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, string(current))
+			current = nil
+		}
+	}
+
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+
+			continue
+		}
+
+		if unicode.IsUpper(r) && len(current) > 0 {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+
+			if !unicode.IsUpper(prev) || nextIsLower {
+				flush()
+			}
+		}
+
+		current = append(current, r)
+	}
+
+	flush()
+
+	return words
+}
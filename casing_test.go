@@ -0,0 +1,61 @@
+package docs
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestUnitOperationIDCaseApply(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in        string
+		wantCamel string
+		wantSnake string
+	}{
+		{"getUser", "getUser", "get_user"},
+		{"get_user_by_id", "getUserById", "get_user_by_id"},
+		{"GetHTTPServer-status", "getHttpServerStatus", "get_http_server_status"},
+		{"list users", "listUsers", "list_users"},
+		{"get_élève", "getÉlève", "get_élève"},
+		{"", "", ""},
+	}
+
+	for _, tt := range tests {
+		if got := OperationIDCamel.apply(tt.in); got != tt.wantCamel {
+			t.Errorf("camel case of %q = %q, want %q", tt.in, got, tt.wantCamel)
+		}
+
+		if got := OperationIDSnake.apply(tt.in); got != tt.wantSnake {
+			t.Errorf("snake case of %q = %q, want %q", tt.in, got, tt.wantSnake)
+		}
+
+		if got := OperationIDAsIs.apply(tt.in); got != tt.in {
+			t.Errorf("as is of %q = %q", tt.in, got)
+		}
+	}
+}
+
+func TestUnitValidateCasedOperationIDs(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Paths = Paths{
+		Path{Route: "/users/{id}", HTTPMethod: "GET", OperationID: "get_user"},
+		Path{Route: "/users/{id}", HTTPMethod: "HEAD", OperationID: "get-user"},
+	}
+
+	if err := o.validateCasedOperationIDs(ConfigBuilder{}); err != nil {
+		t.Errorf("unexpected error for operationIds left as they are: %v", err)
+	}
+
+	err := o.validateCasedOperationIDs(ConfigBuilder{OperationIDCase: OperationIDCamel})
+	if err == nil || !strings.Contains(err.Error(), `duplicate "getUser"`) {
+		t.Errorf("expected an error for the duplicate camel cased operationIds, got %v", err)
+	}
+
+	if _, err := o.BuildDocsBytes(ConfigBuilder{OperationIDCase: OperationIDSnake}); !errors.Is(err, ErrValidation) {
+		t.Errorf("expected a validation error on build, got %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+package docs
+
+import "reflect"
+
+// Clone returns a deep copy of the OAS, so that the copy can be mutated without affecting the original.
+//
+// Registered routes are copied as a new map, while the RouteFn functions themselves are shared.
+func (o *OAS) Clone() *OAS {
+	if o == nil {
+		return nil
+	}
+
+	cloned := deepCopy(reflect.ValueOf(o).Elem()).Addr().Interface().(*OAS) //nolint:forcetypeassert //type is known.
+	cloned.cacheMu = nil
+	cloned.Invalidate()
+
+	return cloned
+}
+
+// deepCopy recursively copies slices, maps, pointers and interfaces of the given value.
+//
+// Unexported struct fields are copied shallowly.
+func deepCopy(src reflect.Value) reflect.Value {
+	return copyValue(src, nil)
+}
+
+// copyValue works like deepCopy, while values held by interfaces are replaced by the output
+// of the marshaler registered for their type, if any.
+func copyValue(src reflect.Value, marshalers map[reflect.Type]ValueMarshalFn) reflect.Value {
+	dst := reflect.New(src.Type()).Elem()
+
+	switch src.Kind() { //nolint:exhaustive //every other kind is copied by value.
+	case reflect.Slice:
+		if src.IsNil() {
+			return dst
+		}
+
+		dst.Set(reflect.MakeSlice(src.Type(), src.Len(), src.Len()))
+
+		for i := 0; i < src.Len(); i++ {
+			dst.Index(i).Set(copyValue(src.Index(i), marshalers))
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			return dst
+		}
+
+		dst.Set(reflect.MakeMapWithSize(src.Type(), src.Len()))
+
+		iter := src.MapRange()
+		for iter.Next() {
+			dst.SetMapIndex(copyValue(iter.Key(), marshalers), copyValue(iter.Value(), marshalers))
+		}
+	case reflect.Ptr:
+		if src.IsNil() {
+			return dst
+		}
+
+		dst.Set(reflect.New(src.Elem().Type()))
+		dst.Elem().Set(copyValue(src.Elem(), marshalers))
+	case reflect.Interface:
+		if src.IsNil() {
+			return dst
+		}
+
+		if marshal, ok := marshalers[src.Elem().Type()]; ok {
+			if marshaled := reflect.ValueOf(marshal(src.Elem().Interface())); marshaled.IsValid() {
+				dst.Set(marshaled)
+			}
+
+			break
+		}
+
+		dst.Set(copyValue(src.Elem(), marshalers))
+	case reflect.Struct:
+		dst.Set(src)
+
+		for i := 0; i < src.NumField(); i++ {
+			if !dst.Field(i).CanSet() {
+				continue
+			}
+
+			dst.Field(i).Set(copyValue(src.Field(i), marshalers))
+		}
+	default:
+		dst.Set(src)
+	}
+
+	return dst
+}
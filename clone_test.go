@@ -0,0 +1,57 @@
+package docs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnitClone(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Servers = Servers{Server{
+		URL:       "https://{env}.example.com",
+		Variables: ServerVariables{"env": {Default: "prod", Enum: []string{"prod"}}},
+	}}
+	o.Paths = Paths{Path{
+		Route:      "/users",
+		HTTPMethod: "GET",
+		Tags:       []string{"user"},
+		Parameters: Parameters{Parameter{Name: "q", In: "query", Schema: &Schema{Type: "string"}}},
+	}}
+	o.Components = Components{Component{Schemas: Schemas{Schema{
+		Name:       "User",
+		Properties: Properties{Property{Name: "id", Schema: Schema{Default: []string{"a"}}}},
+	}}}}
+	o.RegisterCRUD("orders", "#/components/schemas/Order", CRUDOptions{})
+
+	cloned := o.Clone()
+
+	if !reflect.DeepEqual(*cloned, o) {
+		t.Fatalf("expected clone to be equal to the original")
+	}
+
+	cloned.Paths[0].Tags[0] = "internal"
+	cloned.Paths[0].Parameters[0].Schema.Type = "integer"
+	cloned.Servers[0].Variables["env"] = ServerVariable{Default: "dev"}
+	cloned.Components[0].Schemas[0].Properties[0].Schema.Default.([]string)[0] = "b"
+	cloned.Paths = cloned.Paths[:1]
+
+	if o.Paths[0].Tags[0] != "user" ||
+		o.Paths[0].Parameters[0].Schema.Type != "string" ||
+		o.Servers[0].Variables["env"].Default != "prod" ||
+		o.Components[0].Schemas[0].Properties[0].Schema.Default.([]string)[0] != "a" ||
+		len(o.Paths) != 6 {
+		t.Errorf("mutating the clone affected the original: %+v", o)
+	}
+}
+
+func TestUnitCloneNil(t *testing.T) {
+	t.Parallel()
+
+	var o *OAS
+
+	if o.Clone() != nil {
+		t.Error("expected nil clone of a nil OAS")
+	}
+}
@@ -0,0 +1,189 @@
+package docs
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const defaultCRUDIDParam = "id"
+
+// CRUDOptions represents a config structure used by RegisterCRUD.
+//
+// Every verb is registered by default, each of them can be disabled explicitly.
+type CRUDOptions struct {
+	Tag           string
+	IDParam       string // Name of the path parameter identifying a single resource, defaults to "id".
+	ListSchemaRef string // Schema used by the list response, e.g. a ref to an array of resources.
+	Singular      string // Singular of the resource, derived from English plurals by default, e.g. "people" needs it.
+
+	DisableList   bool
+	DisableGet    bool
+	DisableCreate bool
+	DisableUpdate bool
+	DisableDelete bool
+}
+
+func (co CRUDOptions) getIDParam() string {
+	if isStrEmpty(co.IDParam) {
+		return defaultCRUDIDParam
+	}
+
+	return co.IDParam
+}
+
+func (co CRUDOptions) getSingular(resource string) string {
+	if isStrEmpty(co.Singular) {
+		return singularize(resource)
+	}
+
+	return co.Singular
+}
+
+// singularize derives the singular of regular English plurals, e.g. "users", "categories" or "boxes".
+func singularize(plural string) string {
+	lower := strings.ToLower(plural)
+
+	switch {
+	case strings.HasSuffix(lower, "ies") && len(plural) > len("ies"):
+		return plural[:len(plural)-len("ies")] + "y"
+	case strings.HasSuffix(lower, "sses"), strings.HasSuffix(lower, "xes"), strings.HasSuffix(lower, "zes"),
+		strings.HasSuffix(lower, "ches"), strings.HasSuffix(lower, "shes"):
+		return plural[:len(plural)-len("es")]
+	case strings.HasSuffix(lower, "s") && !strings.HasSuffix(lower, "ss"):
+		return plural[:len(plural)-len("s")]
+	default:
+		return plural
+	}
+}
+
+func (co CRUDOptions) getTags() []string {
+	if isStrEmpty(co.Tag) {
+		return []string{}
+	}
+
+	return []string{co.Tag}
+}
+
+// RegisterCRUD appends the standard list, get, create, update and delete operations for the given resource.
+//
+// The resource is used as the route base (e.g. "users" results in "/users" and "/users/{id}"),
+// while schemaRef is used as a content schema for request bodies and single resource responses.
+// Operations on single resources use its singular in their summaries and operationIds, e.g. "getUser".
+func (o *OAS) RegisterCRUD(resource, schemaRef string, opts CRUDOptions) {
+	o.Invalidate()
+
+	resource = strings.Trim(resource, fwSlashSuffix)
+	if isStrEmpty(resource) {
+		return
+	}
+
+	collectionRoute := fwSlashSuffix + resource
+	itemRoute := fmt.Sprintf("%s/{%s}", collectionRoute, opts.getIDParam())
+	singular := opts.getSingular(resource)
+	listOpName := strings.ToUpper(resource[:1]) + resource[1:]
+	opName := strings.ToUpper(singular[:1]) + singular[1:]
+
+	body := RequestBody{
+		Description: fmt.Sprintf("The %s resource", singular),
+		Content:     ContentTypes{crudContentType(schemaRef)},
+		Required:    true,
+	}
+	itemResponse := crudResponse(http.StatusOK, "OK", schemaRef)
+	notFound := crudResponse(http.StatusNotFound, "Not Found", emptyStr)
+
+	if !opts.DisableList {
+		o.Paths = append(o.Paths, Path{
+			Route:       collectionRoute,
+			HTTPMethod:  http.MethodGet,
+			Tags:        opts.getTags(),
+			Summary:     fmt.Sprintf("List %s", resource),
+			OperationID: "list" + listOpName,
+			Responses:   Responses{crudResponse(http.StatusOK, "OK", opts.ListSchemaRef)},
+		})
+	}
+
+	if !opts.DisableGet {
+		o.Paths = append(o.Paths, Path{
+			Route:       itemRoute,
+			HTTPMethod:  http.MethodGet,
+			Tags:        opts.getTags(),
+			Summary:     fmt.Sprintf("Get a single %s", singular),
+			OperationID: "get" + opName,
+			Parameters:  crudIDParams(opts.getIDParam()),
+			Responses:   Responses{itemResponse, notFound},
+		})
+	}
+
+	if !opts.DisableCreate {
+		o.Paths = append(o.Paths, Path{
+			Route:       collectionRoute,
+			HTTPMethod:  http.MethodPost,
+			Tags:        opts.getTags(),
+			Summary:     fmt.Sprintf("Create a new %s", singular),
+			OperationID: "create" + opName,
+			RequestBody: body,
+			Responses: Responses{
+				crudResponse(http.StatusCreated, "Created", schemaRef),
+				crudResponse(http.StatusBadRequest, "Bad Request", emptyStr),
+			},
+		})
+	}
+
+	if !opts.DisableUpdate {
+		o.Paths = append(o.Paths, Path{
+			Route:       itemRoute,
+			HTTPMethod:  http.MethodPut,
+			Tags:        opts.getTags(),
+			Summary:     fmt.Sprintf("Update an existing %s", singular),
+			OperationID: "update" + opName,
+			Parameters:  crudIDParams(opts.getIDParam()),
+			RequestBody: body,
+			Responses:   Responses{itemResponse, notFound},
+		})
+	}
+
+	if !opts.DisableDelete {
+		o.Paths = append(o.Paths, Path{
+			Route:       itemRoute,
+			HTTPMethod:  http.MethodDelete,
+			Tags:        opts.getTags(),
+			Summary:     fmt.Sprintf("Delete an existing %s", singular),
+			OperationID: "delete" + opName,
+			Parameters:  crudIDParams(opts.getIDParam()),
+			Responses: Responses{
+				crudResponse(http.StatusNoContent, "No Content", emptyStr),
+				notFound,
+			},
+		})
+	}
+}
+
+func crudIDParams(name string) Parameters {
+	return Parameters{Parameter{
+		Name:     name,
+		In:       paramInPath,
+		Required: true,
+		Schema:   &Schema{Type: "string"},
+	}}
+}
+
+func crudContentType(schemaRef string) ContentType {
+	return ContentType{
+		Name:   defaultMediaType,
+		Schema: schemaRef,
+	}
+}
+
+func crudResponse(code uint, description, schemaRef string) Response {
+	resp := Response{
+		Code:        code,
+		Description: description,
+	}
+
+	if !isStrEmpty(schemaRef) {
+		resp.Content = ContentTypes{crudContentType(schemaRef)}
+	}
+
+	return resp
+}
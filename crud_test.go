@@ -0,0 +1,90 @@
+package docs
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestUnitRegisterCRUD(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.RegisterCRUD("/users", "#/components/schemas/User", CRUDOptions{Tag: "user"})
+
+	want := []struct {
+		route, method, operationID string
+	}{
+		{"/users", http.MethodGet, "listUsers"},
+		{"/users/{id}", http.MethodGet, "getUser"},
+		{"/users", http.MethodPost, "createUser"},
+		{"/users/{id}", http.MethodPut, "updateUser"},
+		{"/users/{id}", http.MethodDelete, "deleteUser"},
+	}
+
+	if len(o.Paths) != len(want) {
+		t.Fatalf("expected %d paths, got %d", len(want), len(o.Paths))
+	}
+
+	for i, w := range want {
+		got := o.Paths[i]
+		if got.Route != w.route || got.HTTPMethod != w.method || got.OperationID != w.operationID {
+			t.Errorf("unexpected path at %d: got %s %s %s", i, got.HTTPMethod, got.Route, got.OperationID)
+		}
+
+		if len(got.Tags) != 1 || got.Tags[0] != "user" {
+			t.Errorf("expected tag to be set on %s", got.OperationID)
+		}
+	}
+
+	if o.Paths[0].Summary != "List users" || o.Paths[1].Summary != "Get a single user" {
+		t.Errorf("unexpected summaries %q and %q", o.Paths[0].Summary, o.Paths[1].Summary)
+	}
+
+	if !o.Paths[1].Parameters[0].Required {
+		t.Error("expected path parameter to be required")
+	}
+
+	_, err := marshalToYAML(&o)
+	if err != nil {
+		t.Errorf("unexpected error while marshaling CRUD paths: %v", err)
+	}
+}
+
+func TestUnitRegisterCRUDDisabledVerbs(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.RegisterCRUD("orders", "#/components/schemas/Order", CRUDOptions{
+		IDParam:       "orderId",
+		DisableUpdate: true,
+		DisableDelete: true,
+	})
+
+	if len(o.Paths) != 3 {
+		t.Fatalf("expected 3 paths, got %d", len(o.Paths))
+	}
+
+	if o.Paths[1].Route != "/orders/{orderId}" {
+		t.Errorf("unexpected item route: %s", o.Paths[1].Route)
+	}
+}
+
+func TestUnitRegisterCRUDSingular(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.RegisterCRUD("people", "#/components/schemas/Person", CRUDOptions{Singular: "person", DisableList: true})
+
+	if got := o.Paths[0].OperationID; got != "getPerson" {
+		t.Errorf("got operationId %q, but want getPerson", got)
+	}
+
+	for plural, want := range map[string]string{
+		"users": "user", "categories": "category", "boxes": "box", "addresses": "address",
+		"branches": "branch", "access": "access", "data": "data",
+	} {
+		if got := singularize(plural); got != want {
+			t.Errorf("singularize(%q) = %q, want %q", plural, got, want)
+		}
+	}
+}
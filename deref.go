@@ -0,0 +1,216 @@
+package docs
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Dereference returns a copy of the OAS with all internal schema and response refs replaced by their targets,
+// for tooling which cannot resolve refs. The original OAS is left untouched.
+//
+// Component schemas and responses are kept, while external refs are left as they are.
+// Returns an error for refs which do not resolve, and for cyclic schemas, as these cannot be inlined.
+func (o *OAS) Dereference() (*OAS, error) {
+	deref := dereferencer{
+		schemas:   o.componentSchemasByName(),
+		responses: make(map[string]Response),
+	}
+
+	for _, component := range o.Components {
+		for name, resp := range component.Responses {
+			deref.responses[name] = resp
+		}
+	}
+
+	d := o.Clone()
+
+	for i := range d.Paths {
+		if err := deref.path(&d.Paths[i]); err != nil {
+			return nil, fmt.Errorf("%s %s: %w", d.Paths[i].HTTPMethod, d.Paths[i].Route, err)
+		}
+	}
+
+	for i := range d.DefaultResponses {
+		if err := deref.response(&d.DefaultResponses[i]); err != nil {
+			return nil, fmt.Errorf("default response %s: %w", d.DefaultResponses[i].key(), err)
+		}
+	}
+
+	for ci := range d.Components {
+		if err := deref.component(&d.Components[ci]); err != nil {
+			return nil, err
+		}
+	}
+
+	return d, nil
+}
+
+type dereferencer struct {
+	schemas   map[string]*Schema
+	responses map[string]Response
+}
+
+func (d dereferencer) component(component *Component) error {
+	for si := range component.Schemas {
+		name := component.Schemas[si].Name
+
+		if err := d.schema(&component.Schemas[si], []string{name}); err != nil {
+			return fmt.Errorf("component schema %s: %w", name, err)
+		}
+
+		component.Schemas[si].Name = name
+	}
+
+	for name, resp := range component.Responses {
+		if err := d.response(&resp); err != nil {
+			return fmt.Errorf("component response %s: %w", name, err)
+		}
+
+		component.Responses[name] = resp
+	}
+
+	for name, item := range component.PathItems {
+		for i := range item.Operations {
+			if err := d.path(&item.Operations[i]); err != nil {
+				return fmt.Errorf("component path item %s: %w", name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (d dereferencer) path(p *Path) error {
+	for i := range p.Parameters {
+		if p.Parameters[i].Schema != nil {
+			if err := d.schema(p.Parameters[i].Schema, nil); err != nil {
+				return fmt.Errorf("parameter %s: %w", p.Parameters[i].Name, err)
+			}
+		}
+
+		if err := d.content(p.Parameters[i].Content); err != nil {
+			return fmt.Errorf("parameter %s: %w", p.Parameters[i].Name, err)
+		}
+	}
+
+	if err := d.content(p.RequestBody.Content); err != nil {
+		return fmt.Errorf("request body: %w", err)
+	}
+
+	for i := range p.Responses {
+		if err := d.response(&p.Responses[i]); err != nil {
+			return fmt.Errorf("response %s: %w", p.Responses[i].key(), err)
+		}
+	}
+
+	return nil
+}
+
+// response replaces the response by the component one it refers to, keeping its code.
+func (d dereferencer) response(r *Response) error {
+	if strings.HasPrefix(r.Ref, componentResponsesRefPrefix) {
+		target, ok := d.responses[strings.TrimPrefix(r.Ref, componentResponsesRefPrefix)]
+		if !ok {
+			return fmt.Errorf("referenced response %q is not defined", r.Ref)
+		}
+
+		target = deepCopy(reflect.ValueOf(target)).Interface().(Response) //nolint:forcetypeassert //type is known.
+		target.Code, target.CodeRange = r.Code, r.CodeRange
+		*r = target
+	}
+
+	if err := d.content(r.Content); err != nil {
+		return err
+	}
+
+	return d.headers(r.Headers)
+}
+
+func (d dereferencer) headers(headers Headers) error {
+	for name, header := range headers {
+		if header.Schema != nil {
+			if err := d.schema(header.Schema, nil); err != nil {
+				return fmt.Errorf("header %s: %w", name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// content replaces the schema refs of the content types by inline schemas.
+func (d dereferencer) content(cts ContentTypes) error {
+	for i := range cts {
+		if _, ok := schemaNameFromRef(cts[i].Schema); ok {
+			cts[i].InlineSchema = &Schema{Ref: cts[i].Schema}
+			cts[i].Schema = emptyStr
+		}
+
+		if cts[i].InlineSchema != nil {
+			if err := d.schema(cts[i].InlineSchema, nil); err != nil {
+				return fmt.Errorf("%s: %w", cts[i].Name, err)
+			}
+		}
+
+		for property, enc := range cts[i].Encoding {
+			if err := d.headers(enc.Headers); err != nil {
+				return fmt.Errorf("%s: encoding %s: %w", cts[i].Name, property, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// schema replaces the schema and all its subschemas referring to component schemas by copies of these.
+//
+// The path holds the names of the component schemas being inlined, used to detect cycles.
+func (d dereferencer) schema(s *Schema, path []string) error {
+	if name, ok := schemaNameFromRef(s.Ref); ok {
+		for _, inlined := range path {
+			if inlined == name {
+				return fmt.Errorf("cyclic schema refs %s cannot be dereferenced",
+					strings.Join(append(path, name), " -> "))
+			}
+		}
+
+		target, exists := d.schemas[name]
+		if !exists {
+			return fmt.Errorf("referenced schema %q is not defined", s.Ref)
+		}
+
+		*s = deepCopy(reflect.ValueOf(*target)).Interface().(Schema) //nolint:forcetypeassert //type is known.
+		path = append(path[:len(path):len(path)], name)
+	}
+
+	for i := range s.Properties {
+		if err := d.schema(&s.Properties[i].Schema, path); err != nil {
+			return err
+		}
+	}
+
+	if s.Items != nil {
+		if err := d.schema(s.Items, path); err != nil {
+			return err
+		}
+	}
+
+	for _, subSchemas := range []Schemas{s.OneOf, s.AnyOf, s.AllOf} {
+		for i := range subSchemas {
+			if err := d.schema(&subSchemas[i], path); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, ps := range s.PatternProperties {
+		if ps != nil {
+			if err := d.schema(ps, path); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
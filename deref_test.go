@@ -0,0 +1,108 @@
+package docs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnitDereference(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Components = Components{Component{
+		Schemas: Schemas{
+			Schema{Name: "User", Type: "object", Properties: Properties{
+				Property{Name: "address", Schema: Schema{Ref: "#/components/schemas/Address"}},
+			}},
+			Schema{Name: "Address", Type: "object"},
+			Schema{Name: "Error", Type: "object"},
+		},
+		Responses: map[string]Response{"NotFound": {
+			Description: "Not found",
+			Content:     ContentTypes{ContentType{Name: "application/json", Schema: "#/components/schemas/Error"}},
+		}},
+	}}
+	o.Paths = Paths{Path{
+		Route: "/users/{id}", HTTPMethod: "GET",
+		Responses: Responses{
+			Response{Code: 200, Description: "OK", Content: ContentTypes{ContentType{
+				Name: "application/json", Schema: "#/components/schemas/User",
+			}}},
+			Response{Code: 404, Ref: ComponentResponseRef("NotFound")},
+		},
+	}}
+
+	d, err := o.Dereference()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ok := d.Paths[0].Responses[0].Content[0]
+	if ok.Schema != emptyStr || ok.InlineSchema == nil || ok.InlineSchema.Properties[0].Schema.Type != "object" {
+		t.Errorf("expected the nested schema refs to be inlined, got %+v", ok)
+	}
+
+	notFound := d.Paths[0].Responses[1]
+	if notFound.Ref != emptyStr || notFound.Code != 404 || notFound.Description != "Not found" ||
+		notFound.Content[0].InlineSchema == nil {
+		t.Errorf("expected the response ref to be inlined, got %+v", notFound)
+	}
+
+	if got := d.Components[0].Schemas[0].Properties[0].Schema.Ref; got != emptyStr {
+		t.Errorf("expected the component schema refs to be inlined, got %q", got)
+	}
+
+	if o.Paths[0].Responses[1].Ref == emptyStr || o.Paths[0].Responses[0].Content[0].Schema == emptyStr ||
+		o.Components[0].Schemas[0].Properties[0].Schema.Ref == emptyStr {
+		t.Error("expected the original OAS to be left untouched")
+	}
+
+	yml, err := d.BuildDocsBytes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	paths := strings.SplitN(string(yml), "components:", 2)[0]
+	if strings.Contains(paths, "$ref") {
+		t.Errorf("expected no refs in the paths, got:\n%s", paths)
+	}
+}
+
+func TestUnitDereferenceErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		schemas Schemas
+		ref     string
+		wantErr string
+	}{
+		{
+			name: "cyclic",
+			schemas: Schemas{Schema{Name: "Node", Properties: Properties{
+				Property{Name: "next", Schema: Schema{Ref: "#/components/schemas/Node"}},
+			}}},
+			ref:     "#/components/schemas/Node",
+			wantErr: "cyclic schema refs Node -> Node",
+		},
+		{name: "undefined", ref: "#/components/schemas/Missing", wantErr: "is not defined"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			o := New()
+			o.Components = Components{Component{Schemas: tt.schemas}}
+			o.Paths = Paths{Path{Route: "/nodes", HTTPMethod: "GET", Responses: Responses{Response{
+				Code: 200, Content: ContentTypes{ContentType{Name: "application/json", Schema: tt.ref}},
+			}}}}
+
+			_, err := o.Dereference()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
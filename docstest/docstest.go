@@ -0,0 +1,117 @@
+// Package docstest provides utilities for testing the docs built by the docs package,
+// along with a small set of fixtures and their expected YAML output, embedded in the package.
+package docstest
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	docs "github.com/Dev22doo/go-oas-docs"
+)
+
+// UpdateGoldenEnv is the environment variable which, when set to a non empty value,
+// makes AssertBuildsTo (over)write the golden files instead of comparing against them.
+const UpdateGoldenEnv = "DOCS_UPDATE_GOLDEN"
+
+const goldenFilePerm = 0o644
+
+// AssertBuildsTo builds the docs to bytes and reports a test failure if they differ from the golden file.
+//
+// Line endings are normalized before comparison, so golden files checked out with CRLF endings still match.
+func AssertBuildsTo(t testing.TB, o *docs.OAS, goldenPath string) {
+	t.Helper()
+
+	got, err := buildDocs(o)
+	if err != nil {
+		t.Fatalf("failed building docs: %v", err)
+
+		return
+	}
+
+	if os.Getenv(UpdateGoldenEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(goldenPath), os.ModePerm); err != nil {
+			t.Fatalf("failed creating golden file directory: %v", err)
+
+			return
+		}
+
+		if err := os.WriteFile(goldenPath, got, goldenFilePerm); err != nil {
+			t.Fatalf("failed updating golden file: %v", err)
+		}
+
+		return
+	}
+
+	want, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatalf("failed reading golden file (set %s=1 to create it): %v", UpdateGoldenEnv, err)
+
+		return
+	}
+
+	if diff := difference(got, want); diff != "" {
+		t.Errorf("docs differ from golden file %s %s\n(set %s=1 to update it)", goldenPath, diff, UpdateGoldenEnv)
+	}
+}
+
+// AssertBuildsToBytes builds the docs to bytes and reports a test failure if they differ from the expected ones,
+// e.g. the embedded output of a fixture such as MinimalGolden. Line endings are normalized before comparison.
+func AssertBuildsToBytes(t testing.TB, o *docs.OAS, want []byte) {
+	t.Helper()
+
+	got, err := buildDocs(o)
+	if err != nil {
+		t.Fatalf("failed building docs: %v", err)
+
+		return
+	}
+
+	if diff := difference(got, want); diff != "" {
+		t.Errorf("docs differ from the expected ones %s", diff)
+	}
+}
+
+// buildDocs builds the docs of a clone of the OAS, so that the output is never reused from the cache of the OAS.
+func buildDocs(o *docs.OAS) ([]byte, error) {
+	return o.Clone().BuildDocsBytes() //nolint:wrapcheck //reported by the callers.
+}
+
+// difference describes the first differing line of the outputs, or returns an empty string if they are equal.
+func difference(got, want []byte) string {
+	got, want = normalizeLineEndings(got), normalizeLineEndings(want)
+	if bytes.Equal(got, want) {
+		return ""
+	}
+
+	line, gotLine, wantLine := firstDifference(string(got), string(want))
+
+	return fmt.Sprintf("at line %d:\n\tgot:  %q\n\twant: %q", line, gotLine, wantLine)
+}
+
+func normalizeLineEndings(b []byte) []byte {
+	return bytes.ReplaceAll(b, []byte("\r\n"), []byte("\n"))
+}
+
+// firstDifference returns the first differing line number (1 based) and content of both inputs.
+func firstDifference(got, want string) (int, string, string) {
+	gotLines, wantLines := strings.Split(got, "\n"), strings.Split(want, "\n")
+
+	for i := 0; ; i++ {
+		gotLine, wantLine := lineAt(gotLines, i), lineAt(wantLines, i)
+		if gotLine != wantLine || i >= len(gotLines) || i >= len(wantLines) {
+			return i + 1, gotLine, wantLine
+		}
+	}
+}
+
+func lineAt(lines []string, i int) string {
+	if i < len(lines) {
+		return lines[i]
+	}
+
+	return ""
+}
@@ -0,0 +1,116 @@
+package docstest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	docs "github.com/Dev22doo/go-oas-docs"
+)
+
+func TestUnitFixturesBuildToGolden(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		oas    docs.OAS
+		golden string
+	}{
+		{name: "minimal", oas: Minimal(), golden: minimalGoldenPath},
+		{name: "petstore", oas: Petstore(), golden: petstoreGoldenPath},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			AssertBuildsTo(t, &tt.oas, tt.golden)
+
+			if err := tt.oas.ValidateStrict(); err != nil {
+				t.Errorf("expected the fixture to pass the strict validation, got %v", err)
+			}
+		})
+	}
+}
+
+type recorderTB struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorderTB) Helper() {}
+
+func (r *recorderTB) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func (r *recorderTB) Fatalf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func TestUnitAssertBuildsToMismatch(t *testing.T) {
+	t.Parallel()
+
+	golden := filepath.Join(t.TempDir(), "minimal.yaml")
+	if err := os.WriteFile(golden, []byte("openapi: 3.0.3\r\ninfo:\r\n    title: Other\r\n"), goldenFilePerm); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	o := Minimal()
+	rec := &recorderTB{TB: t}
+
+	AssertBuildsTo(rec, &o, golden)
+
+	if len(rec.failures) != 1 {
+		t.Fatalf("expected a single failure, got %v", rec.failures)
+	}
+
+	missing := &recorderTB{TB: t}
+	AssertBuildsTo(missing, &o, filepath.Join(t.TempDir(), "missing.yaml"))
+
+	if len(missing.failures) != 1 {
+		t.Errorf("expected a failure for the missing golden file, got %v", missing.failures)
+	}
+}
+
+func TestUnitAssertBuildsToBytes(t *testing.T) {
+	t.Parallel()
+
+	o := Minimal()
+	AssertBuildsToBytes(t, &o, MinimalGolden())
+
+	rec := &recorderTB{TB: t}
+	AssertBuildsToBytes(rec, &o, PetstoreGolden())
+
+	if len(rec.failures) != 1 {
+		t.Errorf("expected a single failure for the other golden, got %v", rec.failures)
+	}
+
+	if _, err := o.BuildDocsBytes(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	o.Info.Title = "Changed after building"
+	changed := &recorderTB{TB: t}
+	AssertBuildsToBytes(changed, &o, MinimalGolden())
+
+	if len(changed.failures) != 1 {
+		t.Errorf("expected a failure for the docs changed after building, got %v", changed.failures)
+	}
+}
+
+func TestUnitFirstDifference(t *testing.T) {
+	t.Parallel()
+
+	line, got, want := firstDifference("a\nb\nc", "a\nx\nc")
+	if line != 2 || got != "b" || want != "x" {
+		t.Errorf("got line %d (%q, %q)", line, got, want)
+	}
+
+	line, got, want = firstDifference("a\nb", "a\nb\nc")
+	if line != 3 || got != "" || want != "c" {
+		t.Errorf("got line %d (%q, %q)", line, got, want)
+	}
+}
@@ -0,0 +1,73 @@
+package docstest
+
+import (
+	_ "embed" // Required for embedding the golden files.
+
+	docs "github.com/Dev22doo/go-oas-docs"
+)
+
+// Golden files of the fixtures, relative to this package, where these are updated.
+const (
+	minimalGoldenPath  = "testdata/minimal.yaml"
+	petstoreGoldenPath = "testdata/petstore.yaml"
+)
+
+// Expected output of the fixtures, embedded so that these can be used by any package.
+var (
+	//go:embed testdata/minimal.yaml
+	minimalGolden []byte //nolint:gochecknoglobals //embedded.
+
+	//go:embed testdata/petstore.yaml
+	petstoreGolden []byte //nolint:gochecknoglobals //embedded.
+)
+
+// MinimalGolden returns the expected output of Minimal, to be used with AssertBuildsToBytes.
+func MinimalGolden() []byte {
+	return append([]byte(nil), minimalGolden...)
+}
+
+// PetstoreGolden returns the expected output of Petstore, to be used with AssertBuildsToBytes.
+func PetstoreGolden() []byte {
+	return append([]byte(nil), petstoreGolden...)
+}
+
+// Minimal returns the smallest OAS passing the strict validation, containing only the info fields.
+func Minimal() docs.OAS {
+	o := docs.New()
+	o.SetOASVersion("3.0.3")
+	o.Info.Title = "Minimal"
+	o.Info.Version = "1.0.0"
+	o.Info.SetContact("api@example.com")
+
+	return o
+}
+
+// Petstore returns an OAS describing CRUD operations on pets, with a component schema and problem responses.
+func Petstore() docs.OAS {
+	o := docs.New()
+	o.SetOASVersion("3.0.3")
+	o.Info.Title = "Petstore"
+	o.Info.Description = "Sample pet store"
+	o.Info.Version = "1.0.0"
+	o.Info.SetContact("api@example.com")
+	o.Info.SetLicense("MIT", "https://opensource.org/licenses/MIT")
+	o.Servers = docs.Servers{docs.Server{URL: "https://petstore.example.com/v1"}}
+	o.Tags.SetTag("pets", "Everything about pets", docs.ExternalDocs{})
+
+	_ = o.AddComponentSchema(docs.Schema{
+		Name:     "Pet",
+		Type:     "object",
+		Required: []string{"name"},
+		Properties: docs.Properties{
+			docs.Property{Name: "id", Schema: docs.Schema{Type: "integer", Format: "int64", ReadOnly: true}},
+			docs.Property{Name: "name", Schema: docs.Schema{Type: "string", Example: "doggie"}},
+			docs.Property{Name: "status", Schema: docs.Schema{Type: "string", Enum: []string{"available", "sold"}}},
+		},
+	})
+	o.RegisterProblemSchema()
+	o.DefaultResponses = docs.Responses{docs.ProblemResponse(500, "Internal Server Error")}
+
+	o.RegisterCRUD("pets", "#/components/schemas/Pet", docs.CRUDOptions{Tag: "pets"})
+
+	return o
+}
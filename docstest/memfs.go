@@ -0,0 +1,90 @@
+package docstest
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// MemFS is an in-memory docs.WriteFS, which can be set as ConfigBuilder.FS to build the docs without disk I/O.
+//
+// The zero value is ready to use.
+type MemFS struct {
+	mu    sync.Mutex
+	files map[string][]byte
+	dirs  map[string]bool
+}
+
+// Create creates or truncates the named file, whose content is stored once the returned writer is closed.
+func (m *MemFS) Create(name string) (io.WriteCloser, error) {
+	return &memFile{fs: m, name: filepath.Clean(name)}, nil
+}
+
+// MkdirAll records the directory, it never fails.
+func (m *MemFS) MkdirAll(path string, _ os.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.dirs == nil {
+		m.dirs = make(map[string]bool)
+	}
+
+	m.dirs[filepath.Clean(path)] = true
+
+	return nil
+}
+
+// ReadFile returns the content of the named file, and whether it was written at all.
+func (m *MemFS) ReadFile(name string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	content, ok := m.files[filepath.Clean(name)]
+
+	return content, ok
+}
+
+// FileNames returns the sorted names of all written files.
+func (m *MemFS) FileNames() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	names := make([]string, 0, len(m.files))
+	for name := range m.files {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func (m *MemFS) store(name string, content []byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.files == nil {
+		m.files = make(map[string][]byte)
+	}
+
+	m.files[name] = content
+}
+
+type memFile struct {
+	fs   *MemFS
+	name string
+	buf  bytes.Buffer
+}
+
+func (f *memFile) Write(p []byte) (int, error) {
+	return f.buf.Write(p) //nolint:wrapcheck //never fails.
+}
+
+func (f *memFile) Close() error {
+	f.fs.store(f.name, f.buf.Bytes())
+
+	return nil
+}
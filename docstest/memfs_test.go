@@ -0,0 +1,36 @@
+package docstest
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	docs "github.com/Dev22doo/go-oas-docs"
+)
+
+func TestUnitMemFS(t *testing.T) {
+	t.Parallel()
+
+	o := Petstore()
+	memFS := &MemFS{}
+
+	err := o.BuildDocs(docs.ConfigBuilder{CustomPath: "api/openapi.yaml", FS: memFS})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	yml, ok := memFS.ReadFile("api/openapi.yaml")
+	if !ok || !strings.HasPrefix(string(yml), "openapi: 3.0.3\n") {
+		t.Errorf("unexpected output file content: %q", yml)
+	}
+
+	err = o.BuildDocs(docs.ConfigBuilder{CustomPath: "split/openapi.yaml", FS: memFS, Split: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"api/openapi.yaml", "split/openapi.yaml", "split/paths/pets.yaml"}
+	if got := memFS.FileNames(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got files %v, but want %v", got, want)
+	}
+}
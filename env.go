@@ -0,0 +1,90 @@
+package docs
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+)
+
+//nolint:gochecknoglobals //compiled once.
+var envPlaceholderRegex = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// ExpandServerEnv substitutes ${NAME} placeholders in the URLs of all servers, of the document, path items
+// and operations, with the values of the NAME environment variables, e.g. https://${API_HOST}/v1.
+//
+// Only the ${NAME} syntax is substituted, leaving the {name} server variables and a bare $NAME as they are.
+// Returns an error for the first unset environment variable, in which case no URL is modified.
+// Use it on a Clone to produce docs of several environments from the same definition.
+func (o *OAS) ExpandServerEnv() error {
+	servers, err := o.Servers.expandEnv()
+	if err != nil {
+		return err
+	}
+
+	routes := make([]string, 0, len(o.PathItems))
+	for route := range o.PathItems {
+		routes = append(routes, route)
+	}
+
+	sort.Strings(routes)
+
+	items := make(PathItems, len(o.PathItems))
+
+	for _, route := range routes {
+		item := o.PathItems[route]
+
+		item.Servers, err = item.Servers.expandEnv()
+		if err != nil {
+			return fmt.Errorf("%s: %w", route, err)
+		}
+
+		items[route] = item
+	}
+
+	pathServers := make([]Servers, len(o.Paths))
+
+	for i := range o.Paths {
+		pathServers[i], err = o.Paths[i].Servers.expandEnv()
+		if err != nil {
+			return fmt.Errorf("%s %s: %w", o.Paths[i].HTTPMethod, o.Paths[i].Route, err)
+		}
+	}
+
+	o.Invalidate()
+	o.Servers = servers
+
+	for route, item := range items {
+		o.PathItems[route] = item
+	}
+
+	for i := range o.Paths {
+		o.Paths[i].Servers = pathServers[i]
+	}
+
+	return nil
+}
+
+func (ss Servers) expandEnv() (Servers, error) {
+	if ss == nil {
+		return nil, nil
+	}
+
+	expanded := make(Servers, len(ss))
+
+	for i, s := range ss { //nolint:gocritic //consider indexing?
+		placeholders := envPlaceholderRegex.FindAllStringSubmatch(string(s.URL), -1)
+		for _, placeholder := range placeholders {
+			if _, ok := os.LookupEnv(placeholder[1]); !ok {
+				return nil, fmt.Errorf("server %s: environment variable %s is not set", s.URL, placeholder[1])
+			}
+		}
+
+		s.URL = URL(envPlaceholderRegex.ReplaceAllStringFunc(string(s.URL), func(placeholder string) string {
+			return os.Getenv(envPlaceholderRegex.FindStringSubmatch(placeholder)[1])
+		}))
+		expanded[i] = s
+	}
+
+	return expanded, nil
+}
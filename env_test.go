@@ -0,0 +1,50 @@
+package docs
+
+import (
+	"strings"
+	"testing"
+)
+
+//nolint:paralleltest //sets environment variables.
+func TestUnitExpandServerEnv(t *testing.T) {
+	t.Setenv("DOCS_TEST_API_HOST", "api.example.com")
+
+	o := New()
+	o.Servers = Servers{Server{
+		URL:       "https://${DOCS_TEST_API_HOST}/{version}",
+		Variables: ServerVariables{"version": {Default: "v1"}},
+	}}
+	o.Paths = Paths{Path{
+		Route: "/files", HTTPMethod: "GET",
+		Servers: Servers{Server{URL: "https://files.${DOCS_TEST_API_HOST}"}},
+	}}
+	o.SetPathItem("/files", PathItem{Servers: Servers{Server{URL: "https://${DOCS_TEST_API_HOST}/$NOT_EXPANDED"}}})
+
+	if err := o.ExpandServerEnv(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := o.Servers[0].URL; got != "https://api.example.com/{version}" {
+		t.Errorf("unexpected document server URL %q", got)
+	}
+
+	if got := o.PathItems["/files"].Servers[0].URL; got != "https://api.example.com/$NOT_EXPANDED" {
+		t.Errorf("unexpected path item server URL %q", got)
+	}
+
+	if got := o.Paths[0].Servers[0].URL; got != "https://files.api.example.com" {
+		t.Errorf("unexpected operation server URL %q", got)
+	}
+
+	o.Servers = Servers{Server{URL: "https://${DOCS_TEST_API_HOST}"}}
+	o.Paths[0].Servers = Servers{Server{URL: "https://${DOCS_TEST_UNSET}"}}
+
+	err := o.ExpandServerEnv()
+	if err == nil || !strings.Contains(err.Error(), "DOCS_TEST_UNSET is not set") {
+		t.Errorf("expected an error for the unset environment variable, got %v", err)
+	}
+
+	if got := o.Servers[0].URL; got != "https://${DOCS_TEST_API_HOST}" {
+		t.Errorf("expected no URL to be modified on error, got %q", got)
+	}
+}
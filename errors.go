@@ -0,0 +1,45 @@
+package docs
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors returned by BuildDocs and Generate, which can be matched by using errors.Is.
+var (
+	ErrValidation = errors.New("validation issue occurred")
+	ErrMarshal    = errors.New("marshaling issue occurred")
+	ErrWrite      = errors.New("an issue occurred while saving to YAML output")
+	ErrOutdated   = errors.New("the output file is out of date")
+	ErrRead       = errors.New("an issue occurred while reading input files")
+)
+
+// BuildError represents an error which occurred while building the docs.
+//
+// It matches its Kind (one of the sentinel errors) via errors.Is, while unwrapping to the underlying cause.
+type BuildError struct {
+	Kind error
+	Err  error
+}
+
+func newBuildError(kind, err error) *BuildError {
+	return &BuildError{
+		Kind: kind,
+		Err:  err,
+	}
+}
+
+// Error returns the error message, prefixed by its kind.
+func (be *BuildError) Error() string {
+	return fmt.Sprintf("%v: %v", be.Kind, be.Err)
+}
+
+// Unwrap returns the underlying cause.
+func (be *BuildError) Unwrap() error {
+	return be.Err
+}
+
+// Is reports whether the target is the kind of this error.
+func (be *BuildError) Is(target error) bool {
+	return target == be.Kind //nolint:errorlint //sentinels are compared directly.
+}
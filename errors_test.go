@@ -0,0 +1,51 @@
+package docs
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestUnitBuildDocsErrorKinds(t *testing.T) {
+	t.Parallel()
+
+	invalid := New()
+	invalid.Paths = Paths{Path{Route: "/users", HTTPMethod: "GETS"}}
+
+	err := invalid.BuildDocs(ConfigBuilder{CustomPath: os.DevNull})
+	if !errors.Is(err, ErrValidation) || errors.Is(err, ErrWrite) {
+		t.Errorf("expected a validation error, got %v", err)
+	}
+
+	valid := New()
+
+	err = valid.BuildDocs(ConfigBuilder{CustomPath: "./non-existing-dir/out.yaml"})
+	if !errors.Is(err, ErrWrite) {
+		t.Errorf("expected a write error, got %v", err)
+	}
+
+	var pathErr *os.PathError
+	if !errors.As(err, &pathErr) {
+		t.Errorf("expected the underlying cause to be preserved, got %v", err)
+	}
+
+	var buildErr *BuildError
+	if !errors.As(err, &buildErr) || buildErr.Kind != ErrWrite { //nolint:errorlint //sentinel comparison.
+		t.Errorf("expected a BuildError of ErrWrite kind, got %v", err)
+	}
+}
+
+func TestUnitMustBuildDocs(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected MustBuildDocs to panic on a validation error")
+		}
+	}()
+
+	invalid := New()
+	invalid.Paths = Paths{Path{Route: "/users", HTTPMethod: "GETS"}}
+
+	invalid.MustBuildDocs(ConfigBuilder{CustomPath: os.DevNull})
+}
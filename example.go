@@ -0,0 +1,128 @@
+package docs
+
+// ExampleDirection represents the direction of the payload an example is generated for.
+type ExampleDirection int
+
+// Supported ExampleDirection values.
+const (
+	// ExampleForRequest omits readOnly properties from the generated example.
+	ExampleForRequest ExampleDirection = iota
+	// ExampleForResponse omits writeOnly properties from the generated example.
+	ExampleForResponse
+)
+
+// GenerateExample returns an example value for the schema, resolving refs to the component schemas.
+//
+// Explicit examples, defaults and enums are preferred over placeholder values derived from the type.
+// Properties which are readOnly are omitted from request examples, while writeOnly ones are omitted from responses.
+func (o *OAS) GenerateExample(s Schema, direction ExampleDirection) interface{} {
+	return generateExample(&s, direction, o.componentSchemasByName(), make(map[string]bool))
+}
+
+func generateExample(s *Schema, dir ExampleDirection, schemas map[string]*Schema, visiting map[string]bool) interface{} {
+	if name, ok := schemaNameFromRef(s.Ref); ok {
+		target, exists := schemas[name]
+		if !exists || visiting[name] {
+			return nil
+		}
+
+		visiting[name] = true
+		defer delete(visiting, name)
+
+		return generateExample(target, dir, schemas, visiting)
+	}
+
+	switch {
+	case s.Example != nil:
+		return s.Example
+	case len(s.Examples) > 0:
+		return s.Examples[0]
+	case s.Default != nil:
+		return s.Default
+	case len(s.Enum) > 0:
+		return s.Enum[0]
+	case len(s.AllOf) > 0:
+		return generateAllOfExample(s.AllOf, dir, schemas, visiting)
+	case len(s.OneOf) > 0:
+		return generateExample(&s.OneOf[0], dir, schemas, visiting)
+	case len(s.AnyOf) > 0:
+		return generateExample(&s.AnyOf[0], dir, schemas, visiting)
+	}
+
+	switch s.Type {
+	case "object":
+		return generateObjectExample(s, dir, schemas, visiting)
+	case "array":
+		if s.Items == nil {
+			return []interface{}{}
+		}
+
+		return []interface{}{generateExample(s.Items, dir, schemas, visiting)}
+	case "integer":
+		return 0
+	case "number":
+		return 0.0
+	case "boolean":
+		return false
+	case "string":
+		return "string"
+	default:
+		if len(s.Properties) > 0 {
+			return generateObjectExample(s, dir, schemas, visiting)
+		}
+
+		return nil
+	}
+}
+
+func generateObjectExample(
+	s *Schema, dir ExampleDirection, schemas map[string]*Schema, visiting map[string]bool,
+) map[string]interface{} {
+	example := make(map[string]interface{}, len(s.Properties))
+
+	for i := range s.Properties {
+		prop := &s.Properties[i]
+		if isExcludedFromExample(&prop.Schema, dir, schemas) {
+			continue
+		}
+
+		example[prop.Name] = generateExample(&prop.Schema, dir, schemas, visiting)
+	}
+
+	return example
+}
+
+func generateAllOfExample(
+	allOf Schemas, dir ExampleDirection, schemas map[string]*Schema, visiting map[string]bool,
+) map[string]interface{} {
+	example := make(map[string]interface{})
+
+	for i := range allOf {
+		part, ok := generateExample(&allOf[i], dir, schemas, visiting).(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for key, value := range part {
+			example[key] = value
+		}
+	}
+
+	return example
+}
+
+// isExcludedFromExample checks if the property schema (or the schema it refers to) is not relevant for the direction.
+func isExcludedFromExample(s *Schema, dir ExampleDirection, schemas map[string]*Schema) bool {
+	if name, ok := schemaNameFromRef(s.Ref); ok && schemas[name] != nil {
+		s = schemas[name]
+	}
+
+	switch dir {
+	case ExampleForRequest:
+		return s.ReadOnly
+	case ExampleForResponse:
+		return s.WriteOnly
+	default:
+		return false
+	}
+}
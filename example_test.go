@@ -0,0 +1,49 @@
+package docs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnitGenerateExample(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	_ = o.AddComponentSchema(Schema{
+		Name: "User",
+		Type: "object",
+		Properties: Properties{
+			Property{Name: "id", Schema: Schema{Type: "integer", ReadOnly: true}},
+			Property{Name: "username", Schema: Schema{Type: "string", Example: "john"}},
+			Property{Name: "password", Schema: Schema{Type: "string", WriteOnly: true}},
+			Property{Name: "roles", Schema: Schema{Type: "array", Items: &Schema{Type: "string", Enum: []string{"admin"}}}},
+			Property{Name: "manager", Schema: Schema{Ref: "#/components/schemas/User"}},
+		},
+	})
+
+	ref := Schema{Ref: "#/components/schemas/User"}
+
+	gotRequest := o.GenerateExample(ref, ExampleForRequest)
+	wantRequest := map[string]interface{}{
+		"username": "john",
+		"password": "string",
+		"roles":    []interface{}{"admin"},
+		"manager":  nil,
+	}
+
+	if !reflect.DeepEqual(gotRequest, wantRequest) {
+		t.Errorf("request example = %v, want %v", gotRequest, wantRequest)
+	}
+
+	gotResponse := o.GenerateExample(ref, ExampleForResponse)
+	wantResponse := map[string]interface{}{
+		"id":       0,
+		"username": "john",
+		"roles":    []interface{}{"admin"},
+		"manager":  nil,
+	}
+
+	if !reflect.DeepEqual(gotResponse, wantResponse) {
+		t.Errorf("response example = %v, want %v", gotResponse, wantResponse)
+	}
+}
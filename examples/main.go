@@ -108,31 +108,41 @@ func apiSetComponents(apiDoc *docs.OAS) {
 				docs.Schema{
 					Name: "User",
 					Type: "object",
-					Properties: docs.SchemaProperties{
-						docs.SchemaProperty{
-							Name:        "id",
-							Type:        "integer",
-							Format:      "int64",
-							Description: "UserID",
+					Properties: docs.Properties{
+						docs.Property{
+							Name: "id",
+							Schema: docs.Schema{
+								Type:        "integer",
+								Format:      "int64",
+								Description: "UserID",
+							},
 						},
-						docs.SchemaProperty{
+						docs.Property{
 							Name: "username",
-							Type: "string",
+							Schema: docs.Schema{
+								Type: "string",
+							},
 						},
-						docs.SchemaProperty{
+						docs.Property{
 							Name: "email",
-							Type: "string",
+							Schema: docs.Schema{
+								Type: "string",
+							},
 						},
-						docs.SchemaProperty{
-							Name:        "userStatus",
-							Type:        "integer",
-							Description: "User Status",
-							Format:      "int32",
+						docs.Property{
+							Name: "userStatus",
+							Schema: docs.Schema{
+								Type:        "integer",
+								Description: "User Status",
+								Format:      "int32",
+							},
 						},
-						docs.SchemaProperty{
+						docs.Property{
 							Name: "phForEnums",
-							Type: "enum",
-							Enum: []string{"placed", "approved"},
+							Schema: docs.Schema{
+								Type: "enum",
+								Enum: []string{"placed", "approved"},
+							},
 						},
 					},
 					XML: docs.XMLEntry{Name: "User"},
@@ -140,15 +150,19 @@ func apiSetComponents(apiDoc *docs.OAS) {
 				docs.Schema{
 					Name: "Tag",
 					Type: "object",
-					Properties: docs.SchemaProperties{
-						docs.SchemaProperty{
-							Name:   "id",
-							Type:   "integer",
-							Format: "int64",
+					Properties: docs.Properties{
+						docs.Property{
+							Name: "id",
+							Schema: docs.Schema{
+								Type:   "integer",
+								Format: "int64",
+							},
 						},
-						docs.SchemaProperty{
+						docs.Property{
 							Name: "name",
-							Type: "string",
+							Schema: docs.Schema{
+								Type: "string",
+							},
 						},
 					},
 					XML: docs.XMLEntry{Name: "Tag"},
@@ -156,19 +170,25 @@ func apiSetComponents(apiDoc *docs.OAS) {
 				docs.Schema{
 					Name: "ApiResponse",
 					Type: "object",
-					Properties: docs.SchemaProperties{
-						docs.SchemaProperty{
-							Name:   "code",
-							Type:   "integer",
-							Format: "int32",
+					Properties: docs.Properties{
+						docs.Property{
+							Name: "code",
+							Schema: docs.Schema{
+								Type:   "integer",
+								Format: "int32",
+							},
 						},
-						docs.SchemaProperty{
+						docs.Property{
 							Name: "type",
-							Type: "string",
+							Schema: docs.Schema{
+								Type: "string",
+							},
 						},
-						docs.SchemaProperty{
+						docs.Property{
 							Name: "message",
-							Type: "string",
+							Schema: docs.Schema{
+								Type: "string",
+							},
 						},
 					},
 					XML: docs.XMLEntry{Name: "ApiResponse"},
@@ -0,0 +1,98 @@
+package docs
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats supported by Generate.
+const (
+	formatYAML = "yaml"
+	formatJSON = "json"
+)
+
+// Generate builds the docs according to the command line arguments, and is meant to be called from
+// a generator main invoked by go:generate, e.g.:
+//
+//	//go:generate go run ./cmd/docs -out ./api/openapi.yaml
+//
+//	func main() {
+//		if err := docs.Generate(&apiDocs, os.Args[1:]); err != nil {
+//			log.Fatal(err)
+//		}
+//	}
+//
+// Supported flags are -out (output file path), -format (yaml or json) and -check, which compares
+// the existing output file with the generated docs instead of writing it, returning ErrOutdated on mismatch.
+func Generate(o *OAS, args []string) error {
+	fs := flag.NewFlagSet("docs", flag.ContinueOnError)
+	out := fs.String("out", defaultDocsOutPath, "output file path")
+	format := fs.String("format", formatYAML, "output format, yaml or json")
+	check := fs.Bool("check", false, "fail if the output file is not up to date, instead of writing it")
+
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("failed parsing arguments: %w", err)
+	}
+
+	if *format != formatYAML && *format != formatJSON {
+		return fmt.Errorf("unsupported format %q, expected %s or %s", *format, formatYAML, formatJSON)
+	}
+
+	cb := ConfigBuilder{CustomPath: *out}
+
+	if !*check && *format == formatYAML {
+		return o.BuildDocs(cb)
+	}
+
+	generated, err := o.buildDocsInFormat(cb, *format)
+	if err != nil {
+		return err
+	}
+
+	if *check {
+		existing, err := os.ReadFile(*out)
+		if err != nil {
+			return newBuildError(ErrOutdated, err)
+		}
+
+		if !bytes.Equal(existing, generated) {
+			return newBuildError(ErrOutdated, fmt.Errorf("%s differs from the generated docs", *out))
+		}
+
+		return nil
+	}
+
+	err = createYAMLOutFile(cb, *out, generated)
+	if err != nil {
+		return newBuildError(ErrWrite, err)
+	}
+
+	return nil
+}
+
+// buildDocsInFormat always rebuilds the docs, so that the check never compares against stale cached output.
+func (o *OAS) buildDocsInFormat(cb ConfigBuilder, format string) ([]byte, error) {
+	yml, err := o.buildDocsBytes(cb)
+	if err != nil || format == formatYAML {
+		return yml, err
+	}
+
+	var generic map[string]interface{}
+
+	err = yaml.Unmarshal(yml, &generic)
+	if err != nil {
+		return nil, newBuildError(ErrMarshal, err)
+	}
+
+	jsn, err := json.MarshalIndent(generic, "", "  ")
+	if err != nil {
+		return nil, newBuildError(ErrMarshal, err)
+	}
+
+	return append(jsn, '\n'), nil
+}
@@ -0,0 +1,56 @@
+package docs
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUnitGenerate(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Paths = Paths{Path{Route: "/users", HTTPMethod: "GET", OperationID: "getUsers"}}
+
+	dir := t.TempDir()
+	yamlOut := filepath.Join(dir, "openapi.yaml")
+	jsonOut := filepath.Join(dir, "openapi.json")
+
+	if err := Generate(&o, []string{"-out", yamlOut}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := Generate(&o, []string{"-out", yamlOut, "-check"}); err != nil {
+		t.Errorf("expected freshly generated docs to pass the check, got %v", err)
+	}
+
+	if err := Generate(&o, []string{"-out", jsonOut, "-format", "json"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	jsn, err := os.ReadFile(jsonOut)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !json.Valid(jsn) {
+		t.Errorf("expected valid JSON output, got %s", jsn)
+	}
+
+	o.Paths[0].OperationID = "listUsers"
+
+	err = Generate(&o, []string{"-out", yamlOut, "-check"})
+	if !errors.Is(err, ErrOutdated) {
+		t.Errorf("expected an outdated error, got %v", err)
+	}
+
+	if o.cache.valid {
+		t.Error("expected generating to bypass the cache")
+	}
+
+	if err := Generate(&o, []string{"-format", "xml"}); err == nil {
+		t.Error("expected an error for an unsupported format")
+	}
+}
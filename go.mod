@@ -2,7 +2,10 @@ module github.com/Dev22doo/go-oas-docs
 
 go 1.18
 
-require gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b
+require (
+	github.com/santhosh-tekuri/jsonschema/v5 v5.2.0
+	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b
+)
 
 require (
 	github.com/kr/pretty v0.1.0 // indirect
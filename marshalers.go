@@ -0,0 +1,46 @@
+package docs
+
+import "reflect"
+
+// ValueMarshalFn converts a custom typed value to one which marshals as expected, e.g. a decimal to its string.
+type ValueMarshalFn func(value interface{}) interface{}
+
+// RegisterValueMarshaler registers the function converting the values of the same type as the sample one,
+// for types which neither implement yaml.Marshaler nor encoding.TextMarshaler, and marshal as opaque structs.
+//
+// Values of the type are converted on build wherever held by untyped fields, e.g. schema default and examples,
+// including their nested values. The OAS itself is left untouched. A later registration for the type wins.
+func (o *OAS) RegisterValueMarshaler(sample interface{}, fn ValueMarshalFn) {
+	marshalers := make(map[reflect.Type]ValueMarshalFn, len(o.valueMarshalers)+1)
+	for typ, marshal := range o.valueMarshalers {
+		marshalers[typ] = marshal
+	}
+
+	marshalers[reflect.TypeOf(sample)] = fn
+
+	o.Invalidate()
+	o.valueMarshalers = marshalers
+}
+
+// withMarshaledValues returns a copy of the OAS with the values converted by the registered marshalers, if any.
+func (o *OAS) withMarshaledValues() *OAS {
+	marshaled := o.exportedCopy(o.valueMarshalers)
+	marshaled.rawFragments = o.rawFragments
+
+	return marshaled
+}
+
+// exportedCopy returns a deep copy of the exported fields, with the values converted by the given marshalers.
+// Only the exported fields are copied, as the cache and its mutex are held by the ongoing build.
+func (o *OAS) exportedCopy(marshalers map[reflect.Type]ValueMarshalFn) *OAS {
+	copied := &OAS{}
+	src, dst := reflect.ValueOf(o).Elem(), reflect.ValueOf(copied).Elem()
+
+	for i := 0; i < src.NumField(); i++ {
+		if dst.Field(i).CanSet() {
+			dst.Field(i).Set(copyValue(src.Field(i), marshalers))
+		}
+	}
+
+	return copied
+}
@@ -0,0 +1,68 @@
+package docs
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type testDecimal struct {
+	units int64
+	exp   int
+}
+
+type testYAMLMarshaler struct{}
+
+func (testYAMLMarshaler) MarshalYAML() (interface{}, error) {
+	return "marshaled", nil
+}
+
+func TestUnitRegisterValueMarshaler(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.OASVersion = "3.0.3"
+	o.RegisterValueMarshaler(testDecimal{}, func(value interface{}) interface{} {
+		d, _ := value.(testDecimal)
+
+		return fmt.Sprintf("%de%d", d.units, d.exp)
+	})
+
+	price := testDecimal{units: 1999, exp: -2}
+	o.Components = Components{Component{Schemas: Schemas{Schema{
+		Name: "Price", Type: "string", Default: price,
+		Example: map[string]interface{}{"amount": price},
+	}, Schema{
+		Name: "Marshaler", Type: "string", Default: testYAMLMarshaler{},
+	}}}}
+
+	yml, err := o.BuildDocsBytes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []string{`default: "1999e-2"`, `amount: "1999e-2"`, "default: marshaled"} {
+		if !strings.Contains(string(yml), want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, yml)
+		}
+	}
+
+	if o.Components[0].Schemas[0].Default != price {
+		t.Errorf("expected the OAS to be left untouched, got default %v", o.Components[0].Schemas[0].Default)
+	}
+}
+
+func TestUnitRegisterValueMarshalerClone(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.RegisterValueMarshaler(testDecimal{}, func(interface{}) interface{} { return "original" })
+
+	cloned := o.Clone()
+	cloned.RegisterValueMarshaler(0, func(interface{}) interface{} { return "cloned" })
+
+	if len(o.valueMarshalers) != 1 {
+		t.Errorf("expected registering on the clone to leave the original untouched, got %d marshalers",
+			len(o.valueMarshalers))
+	}
+}
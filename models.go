@@ -1,5 +1,16 @@
 package docs
 
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const quickSpecOASVersion = "3.0.3"
+
 // WARNING:
 // Most structures in here are an representation of what is defined in default
 //		Open API Specification documentation, v3.0.3.
@@ -15,20 +26,86 @@ func New() OAS {
 	}
 }
 
+// QuickRoute represents a route descriptor, used by QuickSpec.
+type QuickRoute struct {
+	Method  string
+	Path    string
+	Summary string
+}
+
+// QuickSpec returns a new instance of OAS structure of OAS version 3.0.3, with the given info and a path
+// responding with 200 OK for each of the routes, e.g. for tiny services exposing only a health check.
+//
+// The result passes ValidateStrict, and can be extended further, as any other OAS.
+func QuickSpec(title, version string, routes ...QuickRoute) *OAS {
+	oas := New()
+	oas.OASVersion = quickSpecOASVersion
+	oas.Info.Title = title
+	oas.Info.Version = Version(version)
+	oas.Paths = make(Paths, 0, len(routes))
+
+	for _, route := range routes {
+		oas.Paths = append(oas.Paths, Path{
+			Route:      route.Path,
+			HTTPMethod: route.Method,
+			Summary:    route.Summary,
+			Responses:  Responses{Response{Code: http.StatusOK, Description: http.StatusText(http.StatusOK)}},
+		})
+	}
+
+	return &oas
+}
+
+// RouteInfo represents a route descriptor, used by NewOASFromRoutes.
+type RouteInfo struct {
+	Method      string
+	Path        string
+	HandlerName string
+}
+
+// NewOASFromRoutes returns a new instance of OAS structure, with Paths seeded from the given routes.
+//
+// OperationID of each path defaults to the handler name, and can be overridden along with all other fields.
+func NewOASFromRoutes(routes []RouteInfo) OAS {
+	oas := New()
+	oas.Paths = make(Paths, 0, len(routes))
+
+	for _, route := range routes {
+		oas.Paths = append(oas.Paths, Path{
+			Route:           route.Path,
+			HTTPMethod:      route.Method,
+			OperationID:     route.HandlerName,
+			HandlerFuncName: route.HandlerName,
+		})
+	}
+
+	return oas
+}
+
 const (
 	oasAnnotationInit = "// @OAS "
 )
 
 // OAS - represents Open API Specification structure, in its approximated Go form.
 type OAS struct {
-	OASVersion       OASVersion   `yaml:"openapi"`
-	Info             Info         `yaml:"info"`
-	ExternalDocs     ExternalDocs `yaml:"externalDocs"`
-	Servers          Servers      `yaml:"servers"`
-	Tags             Tags         `yaml:"tags"`
-	Paths            Paths        `yaml:"paths"`
-	Components       Components   `yaml:"components"`
-	RegisteredRoutes RegRoutes    `yaml:"-"`
+	OASVersion        OASVersion   `yaml:"openapi"`
+	JSONSchemaDialect string       `yaml:"-"` // Emitted only for OAS 3.1, defaults to the 3.1 base dialect.
+	Info              Info         `yaml:"info"`
+	ExternalDocs      ExternalDocs `yaml:"externalDocs"`
+	Servers           Servers      `yaml:"servers"`
+	Tags              Tags         `yaml:"tags"`
+	Paths             Paths        `yaml:"paths"`
+	PathItems         PathItems    `yaml:"-"`
+	DefaultResponses  Responses    `yaml:"-"` // Merged into every operation, unless overridden by code.
+	Deprecated        bool         `yaml:"-"` // Marks every operation as deprecated, unless overridden by the path.
+	DefaultMediaType  string       `yaml:"-"` // Names unnamed content with a schema, application/json by default.
+	Components        Components   `yaml:"components"`
+	RegisteredRoutes  RegRoutes    `yaml:"-"`
+
+	cache           docsCache
+	cacheMu         *sync.Mutex
+	rawFragments    []rawFragment
+	valueMarshalers map[reflect.Type]ValueMarshalFn
 }
 
 type (
@@ -42,14 +119,25 @@ type (
 	OASVersion Version
 )
 
+const oasVersion31Prefix = "3.1"
+
+// is31 checks if the version targets OAS 3.1, which enables fields that are not part of OAS 3.0.
+func (v OASVersion) is31() bool {
+	return strings.HasPrefix(string(v), oasVersion31Prefix)
+}
+
 // Info represents OAS info object.
 type Info struct {
-	Title          string  `yaml:"title"`
-	Description    string  `yaml:"description"`
-	TermsOfService URL     `yaml:"termsOfService"`
-	Contact        Contact `yaml:"contact"`
-	License        License `yaml:"license"`
-	Version        Version `yaml:"version"`
+	Title           string  `yaml:"title"`
+	Summary         string  `yaml:"summary,omitempty"` // Emitted only for OAS 3.1.
+	Description     string  `yaml:"description"`
+	DescriptionFile string  `yaml:"-"` // Read at build time (relative to working dir), overriding Description.
+	TermsOfService  URL     `yaml:"termsOfService"`
+	Contact         Contact `yaml:"contact,omitempty"` // Omitted while the email is not set.
+	License         License `yaml:"license"`
+	Version         Version `yaml:"version"`
+
+	Extensions map[string]interface{} `yaml:",inline"` // Keys must start with "x-", e.g. x-logo.
 }
 
 // Contact represents OAS contact object, used by Info.
@@ -59,8 +147,9 @@ type Contact struct {
 
 // License represents OAS license object, used by Info.
 type License struct {
-	Name string `yaml:"name"`
-	URL  URL    `yaml:"url"`
+	Name       string `yaml:"name"`
+	URL        URL    `yaml:"url,omitempty"`
+	Identifier string `yaml:"identifier,omitempty"` // SPDX license expression, e.g. Apache-2.0. Emitted only for OAS 3.1.
 }
 
 // ExternalDocs represents OAS externalDocs object.
@@ -76,7 +165,20 @@ type Servers []Server
 
 // Server represents OAS server object.
 type Server struct {
-	URL URL `yaml:"url"`
+	URL         URL                    `yaml:"url"`
+	Description string                 `yaml:"description,omitempty"`
+	Variables   ServerVariables        `yaml:"variables,omitempty"`
+	Extensions  map[string]interface{} `yaml:",inline"` // Keys must be prefixed with "x-".
+}
+
+// ServerVariables is a map of ServerVariable objects, keyed by the variable name used in the Server URL.
+type ServerVariables map[string]ServerVariable
+
+// ServerVariable represents OAS server variable object, used by Server.
+type ServerVariable struct {
+	Enum        []string `yaml:"enum,omitempty"`
+	Default     string   `yaml:"default"`
+	Description string   `yaml:"description,omitempty"`
 }
 
 // Tags is a slice of Tag objects.
@@ -92,6 +194,20 @@ type Tag struct {
 // Paths is a slice of Path objects.
 type Paths []Path
 
+// PathItems is a map of PathItem objects, keyed by the route they describe.
+type PathItems map[string]PathItem
+
+// PathItem represents fields of OAS path item object which are shared by all operations on the same route.
+type PathItem struct {
+	Summary     string  `yaml:"summary,omitempty"`
+	Description string  `yaml:"description,omitempty"`
+	Servers     Servers `yaml:"servers,omitempty"`
+
+	// Ref points to an externally maintained path item, e.g. ./paths/users.yaml, which the route is emitted as.
+	// A route with a ref cannot have any operations, and the remaining fields are not emitted.
+	Ref string `yaml:"$ref,omitempty"`
+}
+
 // Path represents OAS path object.
 type Path struct {
 	Route           string           `yaml:"route"`
@@ -99,12 +215,32 @@ type Path struct {
 	Tags            []string         `yaml:"tags"`
 	Summary         string           `yaml:"summary"`
 	OperationID     string           `yaml:"operationId"`
+	Parameters      Parameters       `yaml:"parameters,omitempty"`
 	RequestBody     RequestBody      `yaml:"requestBody"`
 	Responses       Responses        `yaml:"responses"`
 	Security        SecurityEntities `yaml:"security,omitempty"`
+	Servers         Servers          `yaml:"servers,omitempty"`
+	Deprecated      *bool            `yaml:"deprecated,omitempty"` // nil inherits OAS.Deprecated.
 	HandlerFuncName string           `yaml:"-"`
 }
 
+// Parameters is a slice of Parameter objects.
+type Parameters []Parameter
+
+// Parameter represents OAS parameter object, used by Path.
+type Parameter struct {
+	Name        string       `yaml:"name"`
+	In          string       `yaml:"in"` // query, header, path or cookie
+	Description string       `yaml:"description,omitempty"`
+	Required    bool         `yaml:"required,omitempty"`
+	Deprecated  bool         `yaml:"deprecated,omitempty"`
+	Schema      *Schema      `yaml:"schema,omitempty"`
+	Content     ContentTypes `yaml:"content,omitempty"` // Alternative to Schema, used for complex serialization.
+
+	AllowEmptyValue bool `yaml:"allowEmptyValue,omitempty"` // Valid only for query parameters, e.g. ?verbose.
+	AllowReserved   bool `yaml:"allowReserved,omitempty"`   // Valid only for query parameters.
+}
+
 // RequestBody represents OAS requestBody object, used by Path.
 type RequestBody struct {
 	Description string       `yaml:"description"`
@@ -117,18 +253,100 @@ type ContentTypes []ContentType
 
 // ContentType represents OAS content type object, used by RequestBody and Response.
 type ContentType struct {
-	Name   string `yaml:"ct-name"`   // e.g. application/json
-	Schema string `yaml:"ct-schema"` // e.g. $ref: '#/components/schemas/Pet'
+	Name     string              `yaml:"ct-name"`   // e.g. application/json, parameters such as charset are kept verbatim.
+	Schema   string              `yaml:"ct-schema"` // e.g. $ref: '#/components/schemas/Pet'
+	Examples Examples            `yaml:"examples,omitempty"`
+	Encoding map[string]Encoding `yaml:"encoding,omitempty"` // Keyed by property names, used by multipart parts.
+
+	InlineSchema *Schema `yaml:"ct-inline-schema,omitempty"` // Used instead of Schema ref, e.g. {type: string} or oneOf.
+}
+
+// Encoding represents OAS encoding object, used by ContentType to describe a single property (part).
+//
+// Style, Explode and AllowReserved describe the serialization of application/x-www-form-urlencoded properties,
+// e.g. explode for arrays sent as repeated fields.
+type Encoding struct {
+	ContentType   string  `yaml:"contentType,omitempty"` // e.g. image/png, or a comma-separated list.
+	Headers       Headers `yaml:"headers,omitempty"`
+	Style         string  `yaml:"style,omitempty"` // form, spaceDelimited, pipeDelimited or deepObject.
+	Explode       *bool   `yaml:"explode,omitempty"`
+	AllowReserved bool    `yaml:"allowReserved,omitempty"`
+}
+
+// Headers is a map of Header objects, keyed by the header names.
+type Headers map[string]Header
+
+// Header represents OAS header object.
+type Header struct {
+	Description string      `yaml:"description,omitempty"`
+	Required    bool        `yaml:"required,omitempty"`
+	Schema      *Schema     `yaml:"schema,omitempty"`
+	Example     interface{} `yaml:"example,omitempty"`
+}
+
+// Examples is a map of Example objects, keyed by their names.
+type Examples map[string]Example
+
+// Example represents OAS example object, used by ContentType.
+//
+// Value and ExternalValue are mutually exclusive.
+type Example struct {
+	Summary       string      `yaml:"summary,omitempty"`
+	Description   string      `yaml:"description,omitempty"`
+	Value         interface{} `yaml:"value,omitempty"`
+	ExternalValue URL         `yaml:"externalValue,omitempty"`
 }
 
 // Responses is a slice of Response objects.
 type Responses []Response
 
 // Response represents OAS response object, used by Path.
+//
+// A response referring to a shared one by Ref can not declare its own headers or content, as siblings of
+// a $ref are not allowed. To extend a shared response, e.g. with a Location header, declare a dedicated
+// response repeating its content instead, or register the extended one as another component response.
 type Response struct {
 	Code        uint         `yaml:"code"`
-	Description string       `yaml:"description"`
+	CodeRange   string       `yaml:"codeRange,omitempty"` // e.g. 2XX or default, used instead of Code.
+	Description string       `yaml:"description"`         // Emitted next to Ref only for OAS 3.1.
 	Content     ContentTypes `yaml:"content"`
+	Headers     Headers      `yaml:"headers,omitempty"`
+	Ref         string       `yaml:"ref,omitempty"` // e.g. $ref: '#/components/responses/NotFound'
+}
+
+// ResponseCodeDefault represents the key of the response used for all codes not covered individually.
+const ResponseCodeDefault = "default"
+
+// key returns the key under which the response is emitted.
+func (r *Response) key() string {
+	if !isStrEmpty(r.CodeRange) {
+		return r.CodeRange
+	}
+
+	return strconv.FormatUint(uint64(r.Code), 10)
+}
+
+// withDefaults returns the responses followed by the defaults for codes which are not already documented.
+func (rs Responses) withDefaults(defaults Responses) Responses {
+	if len(defaults) == 0 {
+		return rs
+	}
+
+	documented := make(map[string]bool, len(rs))
+	for i := range rs {
+		documented[rs[i].key()] = true
+	}
+
+	merged := make(Responses, len(rs), len(rs)+len(defaults))
+	copy(merged, rs)
+
+	for i := range defaults {
+		if !documented[defaults[i].key()] {
+			merged = append(merged, defaults[i])
+		}
+	}
+
+	return merged
 }
 
 // SecurityEntities is a slice of Security objects.
@@ -145,31 +363,131 @@ type Components []Component
 
 // Component represents OAS component object.
 type Component struct {
-	Schemas         Schemas         `yaml:"schemas"`
-	SecuritySchemes SecuritySchemes `yaml:"securitySchemes"`
+	Schemas         Schemas             `yaml:"schemas"`
+	SecuritySchemes SecuritySchemes     `yaml:"securitySchemes"`
+	Responses       map[string]Response `yaml:"responses,omitempty"` // Keyed by names, code fields are ignored.
+	PathItems       ComponentPathItems  `yaml:"pathItems,omitempty"` // Emitted only for OAS 3.1.
+}
+
+// ComponentPathItems is a map of reusable ComponentPathItem objects, keyed by their names.
+type ComponentPathItems map[string]ComponentPathItem
+
+// names returns the sorted names of the path items, so that these are always visited in the same order.
+func (cpis ComponentPathItems) names() []string {
+	names := make([]string, 0, len(cpis))
+	for name := range cpis {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// ComponentPathItem represents a reusable OAS path item object, which can be referenced
+// by using ComponentPathItemRef, e.g. from webhooks and callbacks.
+type ComponentPathItem struct {
+	Summary     string  `yaml:"summary,omitempty"`
+	Description string  `yaml:"description,omitempty"`
+	Servers     Servers `yaml:"servers,omitempty"`
+	Operations  Paths   `yaml:"operations"` // Routes are ignored, operations are keyed by their HTTP methods.
+}
+
+// ComponentSchemaRef returns the ref pointing to the component schema with the given name.
+func ComponentSchemaRef(name string) string {
+	return componentSchemasRefPrefix + name
+}
+
+// ComponentResponseRef returns the ref pointing to the component response with the given name.
+func ComponentResponseRef(name string) string {
+	return componentResponsesRefPrefix + name
+}
+
+// ComponentPathItemRef returns the ref pointing to the component path item with the given name.
+func ComponentPathItemRef(name string) string {
+	return componentPathItemsRefPrefix + name
 }
 
 // Schemas is a slice of Schema objects.
 type Schemas []Schema
 
 // Schema represents OAS schema object, used by Component.
+//
+// Aside from components, it is also used for inline schemas of properties, items and parameters.
 type Schema struct {
-	Name       string
-	Type       string
-	Properties SchemaProperties
-	XML        XMLEntry `yaml:"xml, omitempty"`
-	Ref        string   // $ref: '#/components/schemas/Pet' // TODO: Should this be omitted if empty?
+	Name        string
+	Type        string        // OAS3.0 data types - e.g. object, array, integer, boolean, string
+	Format      string        `yaml:"format,omitempty"`
+	Description string        `yaml:"description,omitempty"`
+	Properties  Properties    `yaml:"properties,omitempty"`
+	Required    []string      `yaml:"required,omitempty"` // Names of required properties.
+	Items       *Schema       `yaml:"items,omitempty"`    // Relevant only for array types.
+	Enum        []string      `yaml:"enum,omitempty"`
+	Default     interface{}   `yaml:"default,omitempty"`
+	XML         XMLEntry      `yaml:"xml, omitempty"`
+	Ref         string        // $ref: '#/components/schemas/Pet'
+	UniqueItems bool          `yaml:"uniqueItems,omitempty"` // Relevant only for array types.
+	Deprecated  bool          `yaml:"deprecated,omitempty"`
+	ReadOnly    bool          `yaml:"readOnly,omitempty"`
+	WriteOnly   bool          `yaml:"writeOnly,omitempty"`
+	Nullable    bool          `yaml:"nullable,omitempty"` // Emitted as a type array with null for OAS 3.1.
+	Example     interface{}   `yaml:"example,omitempty"`  // Emitted as the first of examples for OAS 3.1.
+	Examples    []interface{} `yaml:"examples,omitempty"` // Emitted as a single example for OAS 3.0.
+	Const       interface{}   `yaml:"const,omitempty"`    // Emitted only for OAS 3.1.
+
+	OneOf         Schemas        `yaml:"oneOf,omitempty"`
+	AnyOf         Schemas        `yaml:"anyOf,omitempty"`
+	AllOf         Schemas        `yaml:"allOf,omitempty"`
+	Discriminator *Discriminator `yaml:"discriminator,omitempty"`
+
+	// ContentEncoding and ContentMediaType describe encoded string contents, e.g. base64 and image/png.
+	// They replace e.g. format byte, and are emitted only for OAS 3.1.
+	ContentEncoding  string `yaml:"contentEncoding,omitempty"`
+	ContentMediaType string `yaml:"contentMediaType,omitempty"`
+
+	// PatternProperties maps regular expressions of property names to their schemas. Emitted only for OAS 3.1.
+	PatternProperties map[string]*Schema `yaml:"patternProperties,omitempty"`
 }
 
-// XMLEntry represents name of XML entry in Schema object.
+// Discriminator represents OAS discriminator object, used by Schema with oneOf, anyOf or allOf.
+type Discriminator struct {
+	PropertyName string            `yaml:"propertyName"`
+	Mapping      map[string]string `yaml:"mapping,omitempty"` // Discriminator value to schema ref.
+}
+
+// XMLEntry represents OAS xml object, used by Schema.
 type XMLEntry struct {
-	Name string
+	Name      string `yaml:"name,omitempty"`
+	Namespace string `yaml:"namespace,omitempty"`
+	Prefix    string `yaml:"prefix,omitempty"`
+	Attribute bool   `yaml:"attribute,omitempty"`
+	Wrapped   bool   `yaml:"wrapped,omitempty"` // Relevant only for array types.
+}
+
+// Properties is an ordered slice of Property objects.
+type Properties []Property
+
+// Property represents a named schema, used by Schema.
+type Property struct {
+	Name   string
+	Schema Schema
+}
+
+// RefProperty returns the property referring to the component schema with the given name,
+// e.g. RefProperty("address", "Address") for address: {$ref: '#/components/schemas/Address'}.
+// The ref is checked to resolve by Validate.
+func RefProperty(name, schemaName string) Property {
+	return Property{Name: name, Schema: Schema{Ref: ComponentSchemaRef(schemaName)}}
 }
 
 // SchemaProperties is a slice of SchemaProperty objects.
+//
+// Deprecated: Use Properties instead. Existing SchemaProperties can be converted by using ToProperties.
 type SchemaProperties []SchemaProperty
 
 // SchemaProperty represents OAS schema object, used by Schema.
+//
+// Deprecated: Use Property instead. Existing SchemaProperty can be converted by using ToProperty.
 type SchemaProperty struct {
 	Name        string      `yaml:"-"`
 	Type        string      // OAS3.0 data types - e.g. integer, boolean, string
@@ -177,6 +495,39 @@ type SchemaProperty struct {
 	Description string      `yaml:"description,omitempty"`
 	Enum        []string    `yaml:"enum,omitempty"`
 	Default     interface{} `yaml:"default,omitempty"`
+	UniqueItems bool        `yaml:"uniqueItems,omitempty"` // Relevant only for array types.
+	Deprecated  bool        `yaml:"deprecated,omitempty"`
+	Const       interface{} `yaml:"const,omitempty"` // Emitted only for OAS 3.1.
+	XML         XMLEntry    `yaml:"xml,omitempty"`
+}
+
+// ToProperties converts SchemaProperties to Properties, keeping their order.
+func (sps SchemaProperties) ToProperties() Properties {
+	props := make(Properties, 0, len(sps))
+
+	for _, sp := range sps {
+		props = append(props, sp.ToProperty())
+	}
+
+	return props
+}
+
+// ToProperty converts SchemaProperty to Property.
+func (sp SchemaProperty) ToProperty() Property {
+	return Property{
+		Name: sp.Name,
+		Schema: Schema{
+			Type:        sp.Type,
+			Format:      sp.Format,
+			Description: sp.Description,
+			Enum:        sp.Enum,
+			Default:     sp.Default,
+			UniqueItems: sp.UniqueItems,
+			Deprecated:  sp.Deprecated,
+			Const:       sp.Const,
+			XML:         sp.XML,
+		},
+	}
 }
 
 // SecuritySchemes is a slice of SecuritySchemes objects.
@@ -184,10 +535,11 @@ type SecuritySchemes []SecurityScheme
 
 // SecurityScheme represents OAS security object, used by Component.
 type SecurityScheme struct {
-	Name  string        `yaml:"name,omitempty"`
-	Type  string        `yaml:"type,omitempty"`
-	In    string        `yaml:"in,omitempty"`
-	Flows SecurityFlows `yaml:"flows,omitempty"`
+	Name             string        `yaml:"name,omitempty"`
+	Type             string        `yaml:"type,omitempty"`
+	In               string        `yaml:"in,omitempty"`
+	Flows            SecurityFlows `yaml:"flows,omitempty"`
+	OpenIDConnectURL URL           `yaml:"openIdConnectUrl,omitempty"` // Required by the openIdConnect type.
 }
 
 // SecurityFlows is a slice of SecurityFlow objects.
@@ -209,6 +561,11 @@ type SecurityScope struct {
 	Description string `yaml:"description,omitempty"`
 }
 
+// isEmpty checks if XMLEntry struct is empty.
+func (x XMLEntry) isEmpty() bool {
+	return x == XMLEntry{}
+}
+
 // isEmpty checks if *ExternalDocs struct is empty.
 func (ed *ExternalDocs) isEmpty() bool {
 	if ed == nil {
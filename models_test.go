@@ -2,6 +2,7 @@ package docs
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -19,6 +20,32 @@ func TestUnitNew(t *testing.T) {
 	}
 }
 
+func TestUnitQuickSpec(t *testing.T) {
+	t.Parallel()
+
+	o := QuickSpec("Health", "1.0.0", QuickRoute{Method: "GET", Path: "/healthz", Summary: "Liveness probe"})
+
+	want := Paths{Path{
+		Route:      "/healthz",
+		HTTPMethod: "GET",
+		Summary:    "Liveness probe",
+		Responses:  Responses{Response{Code: 200, Description: "OK"}},
+	}}
+	if !reflect.DeepEqual(o.Paths, want) {
+		t.Errorf("got %+v, but want %+v", o.Paths, want)
+	}
+
+	if err := o.ValidateStrict(); err != nil {
+		t.Errorf("expected a valid spec, got %v", err)
+	}
+
+	o.Info.SetContact("ops@example.com")
+
+	if err := o.ValidateStrict(); err != nil {
+		t.Errorf("expected a valid spec once the contact is set, got %v", err)
+	}
+}
+
 func TestUnitEDIsEmpty(t *testing.T) {
 	t.Parallel()
 
@@ -59,3 +86,119 @@ func TestUnitEDIsEmpty(t *testing.T) {
 		t.Errorf("expected complete ExternalDocs struct")
 	}
 }
+
+func TestUnitOASVersionIs31(t *testing.T) {
+	t.Parallel()
+
+	for ver, want := range map[OASVersion]bool{"3.1.0": true, "3.1": true, "3.0.3": false, "": false} {
+		if got := ver.is31(); got != want {
+			t.Errorf("is31() for %q = %v, want %v", ver, got, want)
+		}
+	}
+}
+
+func TestUnitNewOASFromRoutes(t *testing.T) {
+	t.Parallel()
+
+	got := NewOASFromRoutes([]RouteInfo{
+		{Method: "GET", Path: "/users", HandlerName: "handleGetUser"},
+		{Method: "POST", Path: "/users", HandlerName: "handleCreateUser"},
+	})
+
+	want := Paths{
+		Path{Route: "/users", HTTPMethod: "GET", OperationID: "handleGetUser", HandlerFuncName: "handleGetUser"},
+		Path{Route: "/users", HTTPMethod: "POST", OperationID: "handleCreateUser", HandlerFuncName: "handleCreateUser"},
+	}
+
+	if !reflect.DeepEqual(got.Paths, want) {
+		t.Errorf("got %+v, but want %+v", got.Paths, want)
+	}
+
+	if got.RegisteredRoutes == nil {
+		t.Error("expected registered routes to be initialized")
+	}
+}
+
+func TestUnitXMLEntryIsEmpty(t *testing.T) {
+	t.Parallel()
+
+	if !(XMLEntry{}).isEmpty() {
+		t.Error("expected an empty xml entry")
+	}
+
+	if (XMLEntry{Wrapped: true}).isEmpty() {
+		t.Error("expected a non empty xml entry")
+	}
+}
+
+func TestUnitSchemaPropertiesToProperties(t *testing.T) {
+	t.Parallel()
+
+	sps := SchemaProperties{
+		SchemaProperty{Name: "id", Type: "integer", Format: "int64", Description: "ID"},
+		SchemaProperty{Name: "status", Type: "string", Enum: []string{"placed"}, Deprecated: true},
+	}
+
+	want := Properties{
+		Property{Name: "id", Schema: Schema{Type: "integer", Format: "int64", Description: "ID"}},
+		Property{Name: "status", Schema: Schema{Type: "string", Enum: []string{"placed"}, Deprecated: true}},
+	}
+
+	if got := sps.ToProperties(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, but want %+v", got, want)
+	}
+}
+
+func TestUnitResponsesWithDefaults(t *testing.T) {
+	t.Parallel()
+
+	defaults := Responses{
+		Response{CodeRange: "4XX", Description: "Client error"},
+		Response{Code: 500, Description: "Internal error"},
+	}
+
+	responses := Responses{
+		Response{Code: 200, Description: "OK"},
+		Response{Code: 500, Description: "Database unavailable"},
+	}
+
+	want := Responses{
+		Response{Code: 200, Description: "OK"},
+		Response{Code: 500, Description: "Database unavailable"},
+		Response{CodeRange: "4XX", Description: "Client error"},
+	}
+
+	if got := responses.withDefaults(defaults); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, but want %+v", got, want)
+	}
+
+	if len(responses) != 2 {
+		t.Errorf("expected the original responses to be left untouched, got %+v", responses)
+	}
+}
+
+func TestUnitRefProperty(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.OASVersion = "3.0.3"
+	o.Components = Components{Component{Schemas: Schemas{
+		Schema{Name: "Address", Type: "object"},
+		Schema{Name: "User", Type: "object", Properties: Properties{RefProperty("address", "Address")}},
+	}}}
+
+	yml, err := o.BuildDocsBytes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := "address:\n                    $ref: '#/components/schemas/Address'"; !strings.Contains(string(yml), want) {
+		t.Errorf("expected output to contain %q, got:\n%s", want, yml)
+	}
+
+	o.Components[0].Schemas[1].Properties = append(o.Components[0].Schemas[1].Properties, RefProperty("pet", "Pet"))
+
+	if err := o.Validate(); err == nil || !strings.Contains(err.Error(), "#/components/schemas/Pet") {
+		t.Errorf("expected an error for the unresolved property ref, got %v", err)
+	}
+}
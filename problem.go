@@ -0,0 +1,74 @@
+package docs
+
+const (
+	problemSchemaName = "Problem"
+	problemSchemaRef  = componentSchemasRefPrefix + problemSchemaName
+	problemMediaType  = "application/problem+json"
+)
+
+// ProblemSchema returns the RFC 7807 problem details schema.
+func ProblemSchema() Schema {
+	return Schema{
+		Name: problemSchemaName,
+		Type: "object",
+		Properties: Properties{
+			Property{
+				Name: "type",
+				Schema: Schema{
+					Type:        "string",
+					Format:      "uri",
+					Description: "A URI reference that identifies the problem type",
+				},
+			},
+			Property{
+				Name: "title",
+				Schema: Schema{
+					Type:        "string",
+					Description: "A short, human-readable summary of the problem type",
+				},
+			},
+			Property{
+				Name: "status",
+				Schema: Schema{
+					Type:        "integer",
+					Format:      "int32",
+					Description: "The HTTP status code generated by the origin server",
+				},
+			},
+			Property{
+				Name: "detail",
+				Schema: Schema{
+					Type:        "string",
+					Description: "A human-readable explanation specific to this occurrence of the problem",
+				},
+			},
+			Property{
+				Name: "instance",
+				Schema: Schema{
+					Type:        "string",
+					Format:      "uri",
+					Description: "A URI reference that identifies the specific occurrence of the problem",
+				},
+			},
+		},
+	}
+}
+
+// RegisterProblemSchema adds the RFC 7807 Problem schema to the components, unless it is already present.
+func (o *OAS) RegisterProblemSchema() {
+	_ = o.AddComponentSchema(ProblemSchema()) // An error is returned only if the schema already exists.
+}
+
+// ProblemResponse returns a Response with application/problem+json content, referencing the Problem schema.
+//
+// Make sure to call RegisterProblemSchema so that the referenced schema exists.
+func ProblemResponse(code uint, desc string) Response {
+	return Response{
+		Code:        code,
+		Description: desc,
+		Content: ContentTypes{ContentType{
+			Name:   problemMediaType,
+			Schema: problemSchemaRef,
+		}},
+	}
+}
@@ -0,0 +1,35 @@
+package docs
+
+import "testing"
+
+func TestUnitRegisterProblemSchema(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.RegisterProblemSchema()
+	o.RegisterProblemSchema()
+
+	if len(o.Components) != 1 || len(o.Components[0].Schemas) != 1 {
+		t.Fatalf("expected a single Problem schema, got %+v", o.Components)
+	}
+
+	if o.Components[0].Schemas[0].Name != problemSchemaName {
+		t.Errorf("unexpected schema name: %s", o.Components[0].Schemas[0].Name)
+	}
+}
+
+func TestUnitProblemResponse(t *testing.T) {
+	t.Parallel()
+
+	got := ProblemResponse(404, "Not Found")
+
+	if got.Code != 404 || got.Description != "Not Found" {
+		t.Errorf("unexpected response: %+v", got)
+	}
+
+	if len(got.Content) != 1 ||
+		got.Content[0].Name != problemMediaType ||
+		got.Content[0].Schema != problemSchemaRef {
+		t.Errorf("unexpected problem content: %+v", got.Content)
+	}
+}
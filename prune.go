@@ -0,0 +1,112 @@
+package docs
+
+// prunedComponents returns a copy of the components, without schemas and security schemes
+// which are not referenced by any of the operations included in the build.
+func (o *OAS) prunedComponents(cb ConfigBuilder) Components {
+	var refs []string
+
+	usedSecurity := o.usedSecuritySchemes(func(path *Path) bool { return !cb.isExcluded(path) })
+	included := false
+
+	for i := range o.Paths {
+		path := &o.Paths[i]
+		if cb.isExcluded(path) {
+			continue
+		}
+
+		included = true
+		refs = append(refs, path.schemaRefs()...)
+	}
+
+	if included {
+		for i := range o.DefaultResponses {
+			refs = append(refs, o.DefaultResponses[i].schemaRefs()...)
+		}
+	}
+
+	for _, component := range o.Components {
+		for _, item := range component.PathItems {
+			refs = append(refs, item.schemaRefs()...)
+		}
+
+		for _, resp := range component.Responses {
+			refs = append(refs, resp.schemaRefs()...)
+		}
+	}
+
+	usedSchemas := o.reachableSchemas(refs)
+	pruned := make(Components, 0, len(o.Components))
+
+	for _, component := range o.Components {
+		c := Component{Responses: component.Responses, PathItems: component.PathItems}
+
+		for _, s := range component.Schemas {
+			if usedSchemas[s.Name] {
+				c.Schemas = append(c.Schemas, s)
+			}
+		}
+
+		for _, ss := range component.SecuritySchemes {
+			if usedSecurity[ss.Name] {
+				c.SecuritySchemes = append(c.SecuritySchemes, ss)
+			}
+		}
+
+		pruned = append(pruned, c)
+	}
+
+	return pruned
+}
+
+// UnusedSecuritySchemes returns the names of the security schemes which are not required by any operation,
+// including the ones of component path items, in the order of declaration.
+func (o *OAS) UnusedSecuritySchemes() []string {
+	used := o.usedSecuritySchemes(func(*Path) bool { return true })
+
+	var unused []string
+
+	for _, component := range o.Components {
+		for _, ss := range component.SecuritySchemes {
+			if !used[ss.Name] {
+				unused = append(unused, ss.Name)
+			}
+		}
+	}
+
+	return unused
+}
+
+// usedSecuritySchemes returns the names of the security schemes required by the included paths,
+// and by the operations of component path items, which are always kept.
+func (o *OAS) usedSecuritySchemes(include func(path *Path) bool) map[string]bool {
+	used := make(map[string]bool)
+
+	for i := range o.Paths {
+		if !include(&o.Paths[i]) {
+			continue
+		}
+
+		for _, sec := range o.Paths[i].Security {
+			used[sec.AuthName] = true
+		}
+	}
+
+	for _, component := range o.Components {
+		for _, item := range component.PathItems {
+			for i := range item.Operations {
+				for _, sec := range item.Operations[i].Security {
+					used[sec.AuthName] = true
+				}
+			}
+		}
+	}
+
+	return used
+}
+
+// warnUnusedSecuritySchemes reports the security schemes which are not required by any operation.
+func (o *OAS) warnUnusedSecuritySchemes(cb ConfigBuilder) {
+	for _, name := range o.UnusedSecuritySchemes() {
+		cb.warnf("security scheme %q is not used by any operation", name)
+	}
+}
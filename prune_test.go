@@ -0,0 +1,116 @@
+package docs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUnitPrunedComponents(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Paths = Paths{
+		Path{
+			Route:      "/users",
+			HTTPMethod: "GET",
+			Responses: Responses{Response{
+				Code:    200,
+				Content: ContentTypes{ContentType{Name: "application/json", Schema: "#/components/schemas/UserList"}},
+			}},
+			Security: SecurityEntities{Security{AuthName: "api_key"}},
+		},
+		Path{
+			Route:       "/internal",
+			HTTPMethod:  "POST",
+			Tags:        []string{"internal"},
+			RequestBody: RequestBody{Content: ContentTypes{ContentType{Schema: "#/components/schemas/Secret"}}},
+			Security:    SecurityEntities{Security{AuthName: "internal_auth"}},
+		},
+	}
+	o.Components = Components{Component{
+		Schemas: Schemas{
+			Schema{Name: "UserList", Ref: "#/components/schemas/User"},
+			Schema{Name: "User", Ref: "#/components/schemas/Address"},
+			Schema{Name: "Address"},
+			Schema{Name: "Secret"},
+			Schema{Name: "Unused"},
+		},
+		SecuritySchemes: SecuritySchemes{
+			SecurityScheme{Name: "api_key"},
+			SecurityScheme{Name: "internal_auth"},
+		},
+	}}
+
+	got := o.prunedComponents(ConfigBuilder{ExcludeTags: []string{"internal"}})
+
+	var names []string
+	for _, s := range got[0].Schemas {
+		names = append(names, s.Name)
+	}
+
+	if len(names) != 3 || names[0] != "UserList" || names[1] != "User" || names[2] != "Address" {
+		t.Errorf("unexpected schemas after pruning: %v", names)
+	}
+
+	if len(got[0].SecuritySchemes) != 1 || got[0].SecuritySchemes[0].Name != "api_key" {
+		t.Errorf("unexpected security schemes after pruning: %+v", got[0].SecuritySchemes)
+	}
+
+	if len(o.Components[0].Schemas) != 5 {
+		t.Error("pruning must not modify the original components")
+	}
+
+	o.Components[0].PathItems = ComponentPathItems{"Webhook": ComponentPathItem{Operations: Paths{Path{
+		HTTPMethod: "POST",
+		Security:   SecurityEntities{Security{AuthName: "internal_auth"}},
+	}}}}
+
+	got = o.prunedComponents(ConfigBuilder{ExcludeTags: []string{"internal"}})
+	if len(got[0].SecuritySchemes) != 2 {
+		t.Errorf("expected the scheme used by the component path item to be kept, got %+v", got[0].SecuritySchemes)
+	}
+}
+
+func TestUnitUnusedSecuritySchemes(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Components = Components{Component{
+		SecuritySchemes: SecuritySchemes{
+			SecurityScheme{Name: "apiKey", Type: "apiKey", In: "header"},
+			SecurityScheme{Name: "legacy", Type: "http"},
+			SecurityScheme{Name: "webhookKey", Type: "apiKey", In: "header"},
+		},
+		PathItems: ComponentPathItems{"Notification": ComponentPathItem{Operations: Paths{
+			Path{HTTPMethod: "POST", Security: SecurityEntities{UseSecurity("webhookKey")}},
+		}}},
+	}}
+	o.Paths = Paths{Path{Route: "/users", HTTPMethod: "GET", Security: SecurityEntities{UseSecurity("apiKey")}}}
+
+	if got := o.UnusedSecuritySchemes(); !reflect.DeepEqual(got, []string{"legacy"}) {
+		t.Errorf("got %v, but want [legacy]", got)
+	}
+
+	var msgs []string
+
+	_, err := o.BuildDocsBytes(ConfigBuilder{
+		WarnUnusedSecuritySchemes: true,
+		Logger:                    func(msg string) { msgs = append(msgs, msg) },
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var warnings []string
+
+	for _, msg := range msgs {
+		if strings.HasPrefix(msg, "WARNING") {
+			warnings = append(warnings, msg)
+		}
+	}
+
+	if len(warnings) != 1 || !strings.Contains(warnings[0], `"legacy"`) {
+		t.Errorf("expected a single warning for the unused security scheme, got %v", warnings)
+	}
+}
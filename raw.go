@@ -0,0 +1,136 @@
+package docs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// rawFragment represents a YAML fragment, merged into the output at the JSON pointer location.
+type rawFragment struct {
+	pointer string
+	node    *yaml.Node
+}
+
+// InjectRaw merges the YAML fragment into the output at the JSON pointer location, e.g. /info or
+// /paths/~1users/get, as an escape hatch for features which are not modeled yet.
+//
+// A mapping fragment is merged into the mapping at the location, overriding existing keys,
+// while any fragment is appended to the sequence at the location. Fragments are merged in the order of injection.
+// Returns an error for malformed pointers and fragments, while the location is resolved by BuildDocs,
+// failing with ErrMarshal if it does not point to a mapping or a sequence. Locations are resolved in the
+// written output, so only the ones under /components apply with ComponentsOnly, and none under the paths
+// with Split, as these are moved to separate files.
+func (o *OAS) InjectRaw(pointer string, fragment []byte) error {
+	if !isStrEmpty(pointer) && !strings.HasPrefix(pointer, fwSlashSuffix) {
+		return fmt.Errorf("JSON pointer %q must be empty or start with %q", pointer, fwSlashSuffix)
+	}
+
+	var doc yaml.Node
+
+	if err := yaml.Unmarshal(fragment, &doc); err != nil {
+		return fmt.Errorf("failed unmarshaling raw fragment: %w", err)
+	}
+
+	if len(doc.Content) == 0 {
+		return fmt.Errorf("raw fragment for %q is empty", pointer)
+	}
+
+	o.Invalidate()
+	o.rawFragments = append(o.rawFragments[:len(o.rawFragments):len(o.rawFragments)],
+		rawFragment{pointer: pointer, node: doc.Content[0]})
+
+	return nil
+}
+
+// injectRawFragments merges copies of the fragments into the encoded root node,
+// so that the fragments are not modified by the merge and the following restyling of the output.
+func injectRawFragments(root *yaml.Node, fragments []rawFragment) error {
+	for _, fragment := range fragments {
+		target, err := resolveJSONPointer(root, fragment.pointer)
+		if err != nil {
+			return err
+		}
+
+		switch target.Kind { //nolint:exhaustive //other kinds are not containers.
+		case yaml.MappingNode:
+			if fragment.node.Kind != yaml.MappingNode {
+				return fmt.Errorf("raw fragment for %q must be a mapping, as it is merged into one", fragment.pointer)
+			}
+
+			mergeMappingNodes(target, copyNode(fragment.node))
+		case yaml.SequenceNode:
+			target.Content = append(target.Content, copyNode(fragment.node))
+		default:
+			return fmt.Errorf("JSON pointer %q does not point to a mapping or a sequence", fragment.pointer)
+		}
+	}
+
+	return nil
+}
+
+func resolveJSONPointer(node *yaml.Node, pointer string) (*yaml.Node, error) {
+	if isStrEmpty(pointer) {
+		return node, nil
+	}
+
+	for _, token := range strings.Split(pointer[1:], fwSlashSuffix) {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+
+		next, ok := childNode(node, token)
+		if !ok {
+			return nil, fmt.Errorf("JSON pointer %q does not resolve, %q is not found", pointer, token)
+		}
+
+		node = next
+	}
+
+	return node, nil
+}
+
+func childNode(node *yaml.Node, token string) (*yaml.Node, bool) {
+	switch node.Kind { //nolint:exhaustive //other kinds have no children.
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == token {
+				return node.Content[i+1], true
+			}
+		}
+	case yaml.SequenceNode:
+		index, err := strconv.Atoi(token)
+		if err == nil && index >= 0 && index < len(node.Content) {
+			return node.Content[index], true
+		}
+	}
+
+	return nil, false
+}
+
+// copyNode returns a deep copy of the node and all its children.
+func copyNode(node *yaml.Node) *yaml.Node {
+	copied := *node
+	copied.Content = make([]*yaml.Node, len(node.Content))
+
+	for i, child := range node.Content {
+		copied.Content[i] = copyNode(child)
+	}
+
+	return &copied
+}
+
+// mergeMappingNodes sets the key-value pairs of the source mapping on the target one.
+func mergeMappingNodes(target, source *yaml.Node) {
+	for i := 0; i+1 < len(source.Content); i += 2 {
+		key, value := source.Content[i], source.Content[i+1]
+
+		if existing, ok := childNode(target, key.Value); ok {
+			*existing = *value
+
+			continue
+		}
+
+		target.Content = append(target.Content, key, value)
+	}
+}
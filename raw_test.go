@@ -0,0 +1,157 @@
+package docs
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUnitInjectRaw(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.OASVersion = "3.0.3"
+	o.Paths = Paths{Path{Route: "/users", HTTPMethod: "GET"}}
+	o.Tags = Tags{Tag{Name: "user"}}
+
+	for pointer, fragment := range map[string]string{
+		"/info":              "x-logo:\n  url: https://example.com/logo.png\n",
+		"/paths/~1users/get": "x-rate-limit: 100\noperationId: listAllUsers\n",
+		"/tags":              "name: admin\n",
+	} {
+		if err := o.InjectRaw(pointer, []byte(fragment)); err != nil {
+			t.Fatalf("unexpected error for %s: %v", pointer, err)
+		}
+	}
+
+	yml, err := o.BuildDocsBytes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []string{
+		"    x-logo:\n        url: https://example.com/logo.png\n",
+		"            operationId: listAllUsers\n",
+		"            x-rate-limit: 100\n",
+		"    - name: admin\n",
+	} {
+		if !strings.Contains(string(yml), want) {
+			t.Errorf("expected %q in:\n%s", want, yml)
+		}
+	}
+
+	if strings.Contains(string(yml), "operationId: \"\"") {
+		t.Errorf("expected the existing key to be overridden, got:\n%s", yml)
+	}
+}
+
+func TestUnitInjectRawErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		pointer  string
+		fragment string
+		wantErr  string
+		build    bool
+	}{
+		{name: "malformed pointer", pointer: "info", fragment: "x-a: 1", wantErr: "must be empty or start with"},
+		{name: "malformed fragment", pointer: "/info", fragment: "x-a: [", wantErr: "failed unmarshaling"},
+		{name: "empty fragment", pointer: "/info", fragment: "", wantErr: "is empty"},
+		{name: "unresolved pointer", pointer: "/paths/~1missing", fragment: "x-a: 1", wantErr: "is not found", build: true},
+		{name: "not a container", pointer: "/openapi", fragment: "x-a: 1", wantErr: "mapping or a sequence", build: true},
+		{name: "scalar into mapping", pointer: "/info", fragment: "value", wantErr: "must be a mapping", build: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			o := New()
+
+			err := o.InjectRaw(tt.pointer, []byte(tt.fragment))
+			if tt.build {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				_, err = o.BuildDocsBytes()
+				if !errors.Is(err, ErrMarshal) {
+					t.Errorf("expected ErrMarshal, got %v", err)
+				}
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestUnitInjectRawRepeatedBuilds(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.OASVersion = "3.0.3"
+
+	if err := o.InjectRaw("/info", []byte("x-audiences: [internal, partner]\n")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	block, err := o.BuildDocsBytes(ConfigBuilder{ForceBlockStyle: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(string(block), "    x-audiences:\n        - internal\n") {
+		t.Errorf("expected the fragment in block style, got:\n%s", block)
+	}
+
+	flow, err := o.BuildDocsBytes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(string(flow), "x-audiences: [internal, partner]") {
+		t.Errorf("expected the fragment to keep its style on the following build, got:\n%s", flow)
+	}
+}
+
+func TestUnitInjectRawComponentsOnlyAndSplit(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.OASVersion = "3.0.3"
+	o.Paths = Paths{Path{Route: "/users", HTTPMethod: "GET"}}
+	o.Components = Components{Component{Schemas: Schemas{Schema{Name: "User", Type: "object"}}}}
+
+	if err := o.InjectRaw("/components/schemas/User", []byte("x-internal: true\n")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	yml, err := o.BuildDocsBytes(ConfigBuilder{ComponentsOnly: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(string(yml), "x-internal: true") {
+		t.Errorf("expected the fragment in the components only output, got:\n%s", yml)
+	}
+
+	if err := o.InjectRaw("/paths/~1users/get", []byte("x-rate-limit: 100\n")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := o.BuildDocsBytes(ConfigBuilder{ComponentsOnly: true}); !errors.Is(err, ErrMarshal) {
+		t.Errorf("expected a marshal error for the fragment outside the components, got %v", err)
+	}
+
+	outPath := filepath.Join(t.TempDir(), "openapi.yaml")
+
+	err = o.BuildDocs(ConfigBuilder{CustomPath: outPath, Split: true})
+	if !errors.Is(err, ErrMarshal) || !strings.Contains(err.Error(), "split path files") {
+		t.Errorf("expected a marshal error for the fragment in the split paths, got %v", err)
+	}
+}
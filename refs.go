@@ -0,0 +1,246 @@
+package docs
+
+import "strings"
+
+const (
+	componentSchemasRefPrefix   = "#/components/schemas/"
+	componentPathItemsRefPrefix = "#/components/pathItems/"
+	componentResponsesRefPrefix = "#/components/responses/"
+)
+
+// schemaNameFromRef returns the name of the component schema the ref points to.
+//
+// Returns false if the ref does not point to a component schema, e.g. if it is an external one.
+func schemaNameFromRef(ref string) (string, bool) {
+	if !strings.HasPrefix(ref, componentSchemasRefPrefix) {
+		return emptyStr, false
+	}
+
+	return strings.TrimPrefix(ref, componentSchemasRefPrefix), true
+}
+
+// schemaRefs returns all refs used directly by the path.
+func (p *Path) schemaRefs() []string {
+	var refs []string
+
+	for _, param := range p.Parameters {
+		if param.Schema != nil {
+			refs = append(refs, param.Schema.schemaRefs()...)
+		}
+
+		refs = append(refs, param.Content.schemaRefs()...)
+	}
+
+	refs = append(refs, p.RequestBody.Content.schemaRefs()...)
+
+	for i := range p.Responses {
+		refs = append(refs, p.Responses[i].schemaRefs()...)
+	}
+
+	return refs
+}
+
+// schemaRefs returns all refs used directly by the content and headers of the response.
+func (r *Response) schemaRefs() []string {
+	refs := r.Content.schemaRefs()
+
+	for _, header := range r.Headers {
+		if header.Schema != nil {
+			refs = append(refs, header.Schema.schemaRefs()...)
+		}
+	}
+
+	return refs
+}
+
+// schemaRefs returns all refs used directly by the operations of the path item.
+func (cpi *ComponentPathItem) schemaRefs() []string {
+	var refs []string
+
+	for i := range cpi.Operations {
+		refs = append(refs, cpi.Operations[i].schemaRefs()...)
+	}
+
+	return refs
+}
+
+// schemaRefs returns all refs used directly by the content types.
+func (cts ContentTypes) schemaRefs() []string {
+	refs := make([]string, 0, len(cts))
+
+	for _, ct := range cts {
+		if !isStrEmpty(ct.Schema) {
+			refs = append(refs, ct.Schema)
+		}
+
+		if ct.InlineSchema != nil {
+			refs = append(refs, ct.InlineSchema.schemaRefs()...)
+		}
+
+		for _, enc := range ct.Encoding {
+			for _, header := range enc.Headers {
+				if header.Schema != nil {
+					refs = append(refs, header.Schema.schemaRefs()...)
+				}
+			}
+		}
+	}
+
+	return refs
+}
+
+// schemaRefs returns all refs used directly by the schema.
+func (s *Schema) schemaRefs() []string {
+	var refs []string
+
+	if !isStrEmpty(s.Ref) {
+		refs = append(refs, s.Ref)
+	}
+
+	for i := range s.Properties {
+		refs = append(refs, s.Properties[i].Schema.schemaRefs()...)
+	}
+
+	if s.Items != nil {
+		refs = append(refs, s.Items.schemaRefs()...)
+	}
+
+	for _, subSchemas := range []Schemas{s.OneOf, s.AnyOf, s.AllOf} {
+		for i := range subSchemas {
+			refs = append(refs, subSchemas[i].schemaRefs()...)
+		}
+	}
+
+	if s.Discriminator != nil {
+		for _, ref := range s.Discriminator.Mapping {
+			refs = append(refs, ref)
+		}
+	}
+
+	for _, ps := range s.PatternProperties {
+		if ps != nil {
+			refs = append(refs, ps.schemaRefs()...)
+		}
+	}
+
+	return refs
+}
+
+// componentSchemasByName returns all component schemas, keyed by their names.
+func (o *OAS) componentSchemasByName() map[string]*Schema {
+	schemas := make(map[string]*Schema)
+
+	for ci := range o.Components {
+		for si := range o.Components[ci].Schemas {
+			s := &o.Components[ci].Schemas[si]
+			schemas[s.Name] = s
+		}
+	}
+
+	return schemas
+}
+
+// reachableSchemas returns the names of all component schemas which are reachable from the given refs,
+// following the refs of the schemas themselves transitively.
+func (o *OAS) reachableSchemas(refs []string) map[string]bool {
+	schemas := o.componentSchemasByName()
+	reachable := make(map[string]bool)
+
+	for len(refs) > 0 {
+		ref := refs[len(refs)-1]
+		refs = refs[:len(refs)-1]
+
+		name, ok := schemaNameFromRef(ref)
+		if !ok || reachable[name] {
+			continue
+		}
+
+		reachable[name] = true
+
+		if s, exists := schemas[name]; exists {
+			refs = append(refs, s.schemaRefs()...)
+		}
+	}
+
+	return reachable
+}
+
+// ReferenceCount returns the number of times each ref is used, walking the paths, default responses and components.
+//
+// Refs of all declared component schemas, responses and path items are present, so that the ones with
+// zero count can be considered for removal. Refs used within a component do count, including self references.
+func (o *OAS) ReferenceCount() map[string]int {
+	counts := make(map[string]int)
+
+	for _, component := range o.Components {
+		for _, s := range component.Schemas { //nolint:gocritic //consider indexing?
+			counts[componentSchemasRefPrefix+s.Name] += 0
+		}
+
+		for name := range component.Responses {
+			counts[ComponentResponseRef(name)] += 0
+		}
+
+		for name := range component.PathItems {
+			counts[ComponentPathItemRef(name)] += 0
+		}
+	}
+
+	var refs []string
+
+	for i := range o.Paths {
+		refs = append(refs, o.Paths[i].allRefs()...)
+	}
+
+	for i := range o.DefaultResponses {
+		refs = append(refs, o.DefaultResponses[i].allRefs()...)
+	}
+
+	for ci := range o.Components {
+		component := &o.Components[ci]
+
+		for si := range component.Schemas {
+			refs = append(refs, component.Schemas[si].schemaRefs()...)
+		}
+
+		for _, resp := range component.Responses {
+			refs = append(refs, resp.allRefs()...)
+		}
+
+		for _, item := range component.PathItems {
+			for i := range item.Operations {
+				refs = append(refs, item.Operations[i].allRefs()...)
+			}
+		}
+	}
+
+	for _, ref := range refs {
+		counts[ref]++
+	}
+
+	return counts
+}
+
+// allRefs returns the schema refs used by the path, along with the refs of its responses.
+func (p *Path) allRefs() []string {
+	refs := p.schemaRefs()
+
+	for i := range p.Responses {
+		if !isStrEmpty(p.Responses[i].Ref) {
+			refs = append(refs, p.Responses[i].Ref)
+		}
+	}
+
+	return refs
+}
+
+// allRefs returns the schema refs used by the response, along with the ref of the response itself.
+func (r *Response) allRefs() []string {
+	refs := r.schemaRefs()
+
+	if !isStrEmpty(r.Ref) {
+		refs = append(refs, r.Ref)
+	}
+
+	return refs
+}
@@ -0,0 +1,55 @@
+package docs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnitReferenceCount(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Components = Components{{
+		Schemas: Schemas{
+			{Name: "User", Properties: Properties{{Name: "Friend", Schema: Schema{Ref: "#/components/schemas/User"}}}},
+			{Name: "Error"},
+			{Name: "Unused"},
+		},
+		Responses: map[string]Response{
+			"NotFound": {Description: "Not found", Content: ContentTypes{{
+				Name: "application/json", Schema: "#/components/schemas/Error",
+			}}},
+			"Gone": {Description: "Gone"},
+		},
+	}}
+	o.Paths = Paths{
+		{
+			Route: "/users", HTTPMethod: "GET",
+			Responses: Responses{
+				{Code: 200, Description: "OK", Content: ContentTypes{{
+					Name: "application/json", Schema: "#/components/schemas/User",
+				}}},
+				{Code: 404, Ref: ComponentResponseRef("NotFound")},
+			},
+		},
+		{
+			Route: "/users", HTTPMethod: "POST",
+			RequestBody: RequestBody{Content: ContentTypes{{
+				Name: "application/json", Schema: "#/components/schemas/User",
+			}}},
+			Responses: Responses{{Code: 404, Ref: ComponentResponseRef("NotFound")}},
+		},
+	}
+
+	want := map[string]int{
+		"#/components/schemas/User":       3,
+		"#/components/schemas/Error":      1,
+		"#/components/schemas/Unused":     0,
+		"#/components/responses/NotFound": 2,
+		"#/components/responses/Gone":     0,
+	}
+
+	if got := o.ReferenceCount(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, but want %v", got, want)
+	}
+}
@@ -0,0 +1,28 @@
+package docs
+
+const textPlainMediaType = "text/plain"
+
+// TextResponse returns a Response with text/plain content and an inline string schema,
+// e.g. for health checks returning "OK".
+func TextResponse(code uint, desc string) Response {
+	return Response{
+		Code:        code,
+		Description: desc,
+		Content: ContentTypes{ContentType{
+			Name:         textPlainMediaType,
+			InlineSchema: &Schema{Type: "string"},
+		}},
+	}
+}
+
+// FixedHeader returns a required Header which always has the given value, e.g. no-store for Cache-Control.
+//
+// The value is documented by the schema enum, and const for OAS 3.1, as well as the example.
+func FixedHeader(desc, value string) Header {
+	return Header{
+		Description: desc,
+		Required:    true,
+		Schema:      &Schema{Type: "string", Enum: []string{value}, Const: value},
+		Example:     value,
+	}
+}
@@ -0,0 +1,76 @@
+package docs
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestUnitTextResponse(t *testing.T) {
+	t.Parallel()
+
+	responses := Responses{TextResponse(200, "OK")}
+
+	yml, err := yaml.Marshal(makeResponsesMap(&responses, "3.0.3"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "\"200\":\n    content:\n        text/plain:\n            schema:\n                type: string\n    description: OK\n"
+	if string(yml) != want {
+		t.Errorf("got %q, but want %q", yml, want)
+	}
+
+	o := New()
+	o.Paths = Paths{Path{Route: "/health", HTTPMethod: "GET", Responses: responses}}
+
+	if err := o.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	_ = o.AddComponentSchema(Schema{Name: "Health"})
+	o.Paths[0].Responses[0].Content[0].Schema = "#/components/schemas/Health"
+
+	if err := o.Validate(); err == nil || !strings.Contains(err.Error(), "inline schema") {
+		t.Error("expected an error for both schema and inline schema being set")
+	}
+}
+
+func TestUnitFixedHeader(t *testing.T) {
+	t.Parallel()
+
+	headers := Headers{"Cache-Control": FixedHeader("Caching is disabled", "no-store")}
+
+	tests := []struct {
+		ver  OASVersion
+		want string
+	}{
+		{
+			ver: "3.0.3",
+			want: "Cache-Control:\n    description: Caching is disabled\n    example: no-store\n    required: true\n" +
+				"    schema:\n        enum: [no-store]\n        type: string\n",
+		},
+		{
+			ver: "3.1.0",
+			want: "Cache-Control:\n    description: Caching is disabled\n    example: no-store\n    required: true\n" +
+				"    schema:\n        const: no-store\n        enum: [no-store]\n        type: string\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(string(tt.ver), func(t *testing.T) {
+			t.Parallel()
+
+			yml, err := yaml.Marshal(makeHeadersMap(headers, tt.ver))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if string(yml) != tt.want {
+				t.Errorf("got %q, but want %q", yml, tt.want)
+			}
+		})
+	}
+}
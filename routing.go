@@ -1,6 +1,7 @@
 package docs
 
 import (
+	"fmt"
 	"reflect"
 	"runtime"
 	"strings"
@@ -20,6 +21,8 @@ type (
 //
 // fns param is a slice of functions that satisfy RouteFn signature.
 func (o *OAS) AttachRoutes(fns []RouteFn) {
+	o.Invalidate()
+
 	for _, fn := range fns {
 		fnDeclaration := runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()
 		fields := strings.SplitAfter(fnDeclaration, ".")
@@ -36,5 +39,108 @@ func (o *OAS) GetRegisteredRoutes() RegRoutes {
 
 // GetPathByIndex returns ptr to Path structure, by its index in the parent struct of OAS.
 func (o *OAS) GetPathByIndex(index int) *Path {
+	o.Invalidate()
+
 	return &o.Paths[index]
 }
+
+// AddPath validates the path and appends it to the Paths.
+//
+// The route has to start with a forward slash, the method has to be a valid one and the operationId has to be unique.
+// Existence of referenced schemas is checked later on by Validate, as these can be added after the path.
+func (o *OAS) AddPath(p Path) error {
+	if err := o.checkPath(&p); err != nil {
+		return err
+	}
+
+	o.Invalidate()
+	o.Paths = append(o.Paths, p)
+
+	return nil
+}
+
+// checkPath returns the first issue preventing the path from being added by AddPath.
+func (o *OAS) checkPath(p *Path) error {
+	if !strings.HasPrefix(p.Route, fwSlashSuffix) {
+		return fmt.Errorf("route %q must start with %q", p.Route, fwSlashSuffix)
+	}
+
+	if !isValidHTTPMethod(p.HTTPMethod) {
+		return fmt.Errorf("%s: invalid HTTP method %q", p.Route, p.HTTPMethod)
+	}
+
+	if !isStrEmpty(p.OperationID) {
+		for _, existing := range o.Paths { //nolint:gocritic //consider indexing?
+			if existing.OperationID == p.OperationID {
+				return fmt.Errorf("operationId %q is already used by %s %s",
+					p.OperationID, existing.HTTPMethod, existing.Route)
+			}
+		}
+	}
+
+	return nil
+}
+
+// RemovePath removes the operations matching the route and HTTP method (case-insensitive).
+//
+// The path item of the route is removed as well, once there are no operations left on it.
+// Returns whether any operation was removed.
+func (o *OAS) RemovePath(route, method string) bool {
+	kept := o.Paths[:0]
+	removed := false
+	routeInUse := false
+
+	for _, path := range o.Paths { //nolint:gocritic //consider indexing?
+		if path.Route == route && strings.EqualFold(path.HTTPMethod, method) {
+			removed = true
+
+			continue
+		}
+
+		routeInUse = routeInUse || path.Route == route
+		kept = append(kept, path)
+	}
+
+	if !removed {
+		return false
+	}
+
+	o.Invalidate()
+	o.Paths = kept
+
+	if !routeInUse {
+		delete(o.PathItems, route)
+	}
+
+	return true
+}
+
+// WalkOperations calls fn for each operation in the order of Paths, e.g. to add a common tag or security requirement.
+//
+// The operation can be modified through the pointer, while its route and method are passed for convenience.
+func (o *OAS) WalkOperations(fn func(route, method string, op *Path)) {
+	o.Invalidate()
+
+	for i := range o.Paths {
+		fn(o.Paths[i].Route, o.Paths[i].HTTPMethod, &o.Paths[i])
+	}
+}
+
+// PathsForTag returns the operations carrying the tag, in the order of Paths, or nil if there are none.
+//
+// The operations are returned as copies, so the OAS is not modified by changing them.
+func (o *OAS) PathsForTag(tag string) []Path {
+	var tagged []Path
+
+	for _, path := range o.Paths {
+		for _, pathTag := range path.Tags {
+			if pathTag == tag {
+				tagged = append(tagged, path)
+
+				break
+			}
+		}
+	}
+
+	return tagged
+}
@@ -268,3 +268,128 @@ func TestQuickUnitAttachRoutes(t *testing.T) {
 		t.Errorf("Check failed: %#v", err)
 	}
 }
+
+func TestUnitAddPath(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+
+	if err := o.AddPath(Path{Route: "/users", HTTPMethod: http.MethodGet, OperationID: "getUsers"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	invalid := []Path{
+		{Route: "users", HTTPMethod: http.MethodGet},
+		{Route: "/users", HTTPMethod: "GETS"},
+		{Route: "/users/{id}", HTTPMethod: http.MethodGet, OperationID: "getUsers"},
+	}
+
+	for _, p := range invalid {
+		if err := o.AddPath(p); err == nil {
+			t.Errorf("expected an error for path %+v", p)
+		}
+	}
+
+	if len(o.Paths) != 1 {
+		t.Errorf("expected only the valid path to be added, got %d paths", len(o.Paths))
+	}
+}
+
+func TestUnitRemovePath(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Paths = Paths{
+		Path{Route: "/users", HTTPMethod: "GET"},
+		Path{Route: "/users", HTTPMethod: "POST"},
+		Path{Route: "/internal", HTTPMethod: "GET"},
+	}
+	o.SetPathItem("/users", PathItem{Summary: "Users"})
+	o.SetPathItem("/internal", PathItem{Summary: "Internal"})
+
+	if !o.RemovePath("/internal", "get") {
+		t.Error("expected the path to be removed")
+	}
+
+	if o.RemovePath("/internal", "GET") {
+		t.Error("expected nothing to be removed for an already removed path")
+	}
+
+	if !o.RemovePath("/users", "POST") {
+		t.Error("expected the path to be removed")
+	}
+
+	if len(o.Paths) != 1 || o.Paths[0].HTTPMethod != "GET" {
+		t.Errorf("unexpected remaining paths: %+v", o.Paths)
+	}
+
+	if _, ok := o.PathItems["/internal"]; ok {
+		t.Error("expected the path item without operations to be removed")
+	}
+
+	if _, ok := o.PathItems["/users"]; !ok {
+		t.Error("expected the path item with remaining operations to be kept")
+	}
+}
+
+func TestUnitWalkOperations(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Paths = Paths{
+		Path{Route: "/users", HTTPMethod: "GET"},
+		Path{Route: "/users", HTTPMethod: "POST", Tags: []string{"user"}},
+	}
+
+	var visited []string
+
+	o.WalkOperations(func(route, method string, op *Path) {
+		visited = append(visited, method+" "+route)
+		op.Tags = append(op.Tags, "rate-limited")
+	})
+
+	if want := []string{"GET /users", "POST /users"}; !reflect.DeepEqual(visited, want) {
+		t.Errorf("got %v, but want %v", visited, want)
+	}
+
+	if !reflect.DeepEqual(o.Paths[0].Tags, []string{"rate-limited"}) ||
+		!reflect.DeepEqual(o.Paths[1].Tags, []string{"user", "rate-limited"}) {
+		t.Errorf("expected the operations to be modified, got %+v", o.Paths)
+	}
+}
+
+func TestUnitPathsForTag(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Paths = Paths{
+		Path{Route: "/users", HTTPMethod: "GET", Tags: []string{"user"}},
+		Path{Route: "/pets", HTTPMethod: "GET", Tags: []string{"pet"}},
+		Path{Route: "/users", HTTPMethod: "POST", Tags: []string{"admin", "user"}},
+	}
+
+	tests := []struct {
+		tag  string
+		want []string
+	}{
+		{tag: "user", want: []string{"GET /users", "POST /users"}},
+		{tag: "pet", want: []string{"GET /pets"}},
+		{tag: "unknown"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.tag, func(t *testing.T) {
+			t.Parallel()
+
+			var got []string
+			for _, path := range o.PathsForTag(tt.tag) {
+				got = append(got, path.HTTPMethod+" "+path.Route)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, but want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package docs
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	scanRouteAnnotation   = "@route"
+	scanSummaryAnnotation = "@summary"
+	scanTagAnnotation     = "@tag"
+	testFileSuffix        = "_test.go"
+)
+
+// ScanHandlers parses the Go files in the directory tree, returning a Path stub for each function
+// documented with a route annotation, in the order of files and declarations.
+//
+// Supported annotations are placed in the doc comment of the function, one per line:
+//
+//	// @route GET /users/{id}
+//	// @summary Returns the user.
+//	// @tag users
+//	func GetUser(w http.ResponseWriter, r *http.Request) {}
+//
+// The route annotation is required, while summary and tag are optional, the latter one repeatable.
+// Templated path segments, e.g. {id}, become required string path parameters.
+// The name of the function is used as the operationId and handler function name. Test files are skipped.
+func ScanHandlers(dir string) ([]Path, error) {
+	var paths []Path
+
+	fset := token.NewFileSet()
+
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() || filepath.Ext(path) != goFileExt || strings.HasSuffix(path, testFileSuffix) {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return fmt.Errorf("failed parsing %s: %w", path, err)
+		}
+
+		filePaths, err := scanFileHandlers(fset, file)
+		if err != nil {
+			return err
+		}
+
+		paths = append(paths, filePaths...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed scanning handlers in %s: %w", dir, err)
+	}
+
+	return paths, nil
+}
+
+func scanFileHandlers(fset *token.FileSet, file *ast.File) ([]Path, error) {
+	var paths []Path
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Doc == nil {
+			continue
+		}
+
+		path, found, err := parseHandlerAnnotations(fn)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", fset.Position(fn.Pos()), fn.Name.Name, err)
+		}
+
+		if found {
+			paths = append(paths, path)
+		}
+	}
+
+	return paths, nil
+}
+
+// parseHandlerAnnotations returns the Path described by the doc comment of the function,
+// and whether the comment holds a route annotation at all.
+func parseHandlerAnnotations(fn *ast.FuncDecl) (Path, bool, error) {
+	path := Path{OperationID: fn.Name.Name, HandlerFuncName: fn.Name.Name}
+	found := false
+
+	for _, line := range strings.Split(fn.Doc.Text(), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case scanRouteAnnotation:
+			if len(fields) != 3 || !isValidHTTPMethod(fields[1]) || !strings.HasPrefix(fields[2], fwSlashSuffix) {
+				return Path{}, false, fmt.Errorf("malformed annotation %q, expected %s METHOD /path",
+					line, scanRouteAnnotation)
+			}
+
+			// Templated segments become required path parameters, as for http.ServeMux patterns.
+			route, err := parseServeMuxPattern(fields[1] + " " + fields[2])
+			if err != nil {
+				return Path{}, false, fmt.Errorf("malformed annotation %q: %w", line, err)
+			}
+
+			path.HTTPMethod, path.Route, path.Parameters = route.HTTPMethod, route.Route, route.Parameters
+			found = true
+		case scanSummaryAnnotation:
+			path.Summary = strings.Join(fields[1:], " ")
+		case scanTagAnnotation:
+			path.Tags = append(path.Tags, fields[1:]...)
+		}
+	}
+
+	return path, found, nil
+}
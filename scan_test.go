@@ -0,0 +1,53 @@
+package docs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUnitScanHandlers(t *testing.T) {
+	t.Parallel()
+
+	paths, err := ScanHandlers("testdata/handlers")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []Path{
+		{Route: "/healthz", HTTPMethod: "GET", OperationID: "Healthz", HandlerFuncName: "Healthz"},
+		{
+			Route: "/users/{id}", HTTPMethod: "GET", Summary: "Returns the user.", Tags: []string{"users"},
+			OperationID: "GetUser", HandlerFuncName: "GetUser",
+			Parameters: Parameters{Parameter{Name: "id", In: "path", Required: true, Schema: &Schema{Type: "string"}}},
+		},
+		{
+			Route: "/users", HTTPMethod: "POST", Tags: []string{"users", "admin"},
+			OperationID: "CreateUser", HandlerFuncName: "CreateUser",
+		},
+	}
+
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("got %+v, but want %+v", paths, want)
+	}
+
+	o := New()
+	o.Paths = paths
+
+	if err := o.Validate(); err != nil {
+		t.Errorf("expected the scanned stubs to pass the validation, got %v", err)
+	}
+}
+
+func TestUnitScanHandlersErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := ScanHandlers("testdata/handlers_malformed")
+	if err == nil || !strings.Contains(err.Error(), "malformed.go:4:1: ListUsers: malformed annotation") {
+		t.Errorf("expected an error naming the position of the malformed annotation, got %v", err)
+	}
+
+	if _, err := ScanHandlers("testdata/missing"); err == nil {
+		t.Error("expected an error for the missing directory")
+	}
+}
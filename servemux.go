@@ -0,0 +1,103 @@
+package docs
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	serveMuxEndWildcard   = "{$}"
+	serveMuxRestWildcard  = "..."
+	serveMuxPatternFields = 2
+)
+
+//nolint:gochecknoglobals //compiled once.
+var serveMuxWildcardRegex = regexp.MustCompile(`^{([A-Za-z_][A-Za-z0-9_]*)(\.\.\.)?}$`)
+
+// RegisterServeMuxPatterns appends a path for each of the http.ServeMux patterns (Go 1.22+ routing),
+// e.g. "GET /users/{id}" or "POST example.com/files/{path...}".
+//
+// Hosts are dropped, {name} and {name...} wildcards become required path parameters, while {$} is removed.
+// Patterns have to declare a method, as the documented operations can not match all methods.
+// Either all of the patterns are registered, or none of them as soon as one is invalid.
+func (o *OAS) RegisterServeMuxPatterns(patterns ...string) error {
+	paths := make(Paths, 0, len(patterns))
+
+	for _, pattern := range patterns {
+		path, err := parseServeMuxPattern(pattern)
+		if err != nil {
+			return fmt.Errorf("pattern %q: %w", pattern, err)
+		}
+
+		if err := o.checkPath(&path); err != nil {
+			return fmt.Errorf("pattern %q: %w", pattern, err)
+		}
+
+		paths = append(paths, path)
+	}
+
+	o.Invalidate()
+	o.Paths = append(o.Paths, paths...)
+
+	return nil
+}
+
+func parseServeMuxPattern(pattern string) (Path, error) {
+	fields := strings.Fields(pattern)
+	if len(fields) != serveMuxPatternFields {
+		return Path{}, fmt.Errorf("expected a method followed by a path")
+	}
+
+	method, route := fields[0], fields[1]
+
+	slashIdx := strings.Index(route, fwSlashSuffix)
+	if slashIdx < 0 {
+		return Path{}, fmt.Errorf("path has to start with %q", fwSlashSuffix)
+	}
+
+	route = route[slashIdx:] // Drops the host.
+
+	segments := strings.Split(route, fwSlashSuffix)
+	params := Parameters{}
+
+	for i, segment := range segments {
+		if !strings.ContainsAny(segment, "{}") {
+			continue
+		}
+
+		if segment == serveMuxEndWildcard && i == len(segments)-1 {
+			segments[i] = emptyStr
+
+			continue
+		}
+
+		match := serveMuxWildcardRegex.FindStringSubmatch(segment)
+		if match == nil {
+			return Path{}, fmt.Errorf("invalid wildcard segment %q", segment)
+		}
+
+		if match[2] == serveMuxRestWildcard && i != len(segments)-1 {
+			return Path{}, fmt.Errorf("wildcard %q has to be the last segment", segment)
+		}
+
+		segments[i] = "{" + match[1] + "}"
+		params = append(params, Parameter{
+			Name:     match[1],
+			In:       paramInPath,
+			Required: true,
+			Schema:   &Schema{Type: "string"},
+		})
+	}
+
+	path := Path{
+		Route:      strings.Join(segments, fwSlashSuffix),
+		HTTPMethod: strings.ToUpper(method),
+	}
+
+	if len(params) > 0 {
+		path.Parameters = params
+	}
+
+	return path, nil
+}
@@ -0,0 +1,104 @@
+package docs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnitParseServeMuxPattern(t *testing.T) {
+	t.Parallel()
+
+	idParam := func(name string) Parameter {
+		return Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}}
+	}
+
+	tests := []struct {
+		name    string
+		pattern string
+		want    Path
+		wantErr bool
+	}{
+		{
+			name:    "static route",
+			pattern: "GET /users",
+			want:    Path{Route: "/users", HTTPMethod: "GET"},
+		},
+		{
+			name:    "wildcard",
+			pattern: "DELETE /users/{id}",
+			want:    Path{Route: "/users/{id}", HTTPMethod: "DELETE", Parameters: Parameters{idParam("id")}},
+		},
+		{
+			name:    "rest wildcard with host",
+			pattern: "GET example.com/files/{path...}",
+			want:    Path{Route: "/files/{path}", HTTPMethod: "GET", Parameters: Parameters{idParam("path")}},
+		},
+		{
+			name:    "end wildcard",
+			pattern: "GET /{$}",
+			want:    Path{Route: "/", HTTPMethod: "GET"},
+		},
+		{
+			name:    "missing method",
+			pattern: "/users",
+			wantErr: true,
+		},
+		{
+			name:    "partial wildcard",
+			pattern: "GET /users/id-{id}",
+			wantErr: true,
+		},
+		{
+			name:    "rest wildcard in the middle",
+			pattern: "GET /files/{path...}/raw",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseServeMuxPattern(tt.pattern)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseServeMuxPattern() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, but want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnitRegisterServeMuxPatterns(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+
+	err := o.RegisterServeMuxPatterns("GET /users", "post /users", "GET /users/{id}")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(o.Paths) != 3 || o.Paths[1].HTTPMethod != "POST" {
+		t.Errorf("unexpected paths: %+v", o.Paths)
+	}
+
+	if err := o.Validate(); err != nil {
+		t.Errorf("unexpected validation error: %v", err)
+	}
+
+	if err := o.RegisterServeMuxPatterns("CONNECT /tunnel"); err == nil {
+		t.Error("expected an error for the invalid method")
+	}
+
+	if err := o.RegisterServeMuxPatterns("DELETE /users/{id}", "GET /files/{path...}/raw"); err == nil {
+		t.Error("expected an error for the misplaced rest wildcard")
+	}
+
+	if len(o.Paths) != 3 {
+		t.Errorf("expected no pattern to be registered on failure, got %+v", o.Paths)
+	}
+}
@@ -1,12 +1,18 @@
 package docs
 
+import "fmt"
+
 // SetOASVersion sets the OAS version, by casting string to OASVersion type.
 func (o *OAS) SetOASVersion(ver string) {
+	o.Invalidate()
+
 	o.OASVersion = OASVersion(ver)
 }
 
 // GetInfo returns pointer to the Info struct.
 func (o *OAS) GetInfo() *Info {
+	o.Invalidate()
+
 	return &o.Info
 }
 
@@ -23,6 +29,21 @@ func (i *Info) SetLicense(licType, url string) {
 	}
 }
 
+// AddServer appends a server with the given URL and description, returning the OAS for chaining.
+func (o *OAS) AddServer(url, description string) *OAS {
+	return o.AddServerWithVariables(url, description, nil)
+}
+
+// AddServerWithVariables appends a server with the given URL, description and variables used in the URL,
+// returning the OAS for chaining.
+func (o *OAS) AddServerWithVariables(url, description string, variables ServerVariables) *OAS {
+	o.Invalidate()
+
+	o.Servers = append(o.Servers, Server{URL: URL(url), Description: description, Variables: variables})
+
+	return o
+}
+
 // SetTag is used to define a new tag based on input params, and append it to the slice of tags its being called from.
 func (tt *Tags) SetTag(name, tagDescription string, extDocs ExternalDocs) {
 	var tag Tag
@@ -46,3 +67,103 @@ func (tt *Tags) SetTag(name, tagDescription string, extDocs ExternalDocs) {
 func (tt *Tags) AppendTag(tag *Tag) {
 	*tt = append(*tt, *tag)
 }
+
+// SetPathItem sets the route level summary and description, shared by all operations on that route.
+func (o *OAS) SetPathItem(route string, item PathItem) {
+	o.Invalidate()
+
+	if o.PathItems == nil {
+		o.PathItems = make(PathItems)
+	}
+
+	o.PathItems[route] = item
+}
+
+// AddComponentSchema adds the schema to the canonical (first) Component.
+//
+// Returns an error if a schema with the same name is already defined in any of the components.
+func (o *OAS) AddComponentSchema(s Schema) error {
+	for _, component := range o.Components {
+		for _, existing := range component.Schemas {
+			if existing.Name == s.Name {
+				return fmt.Errorf("component schema %q is already defined", s.Name)
+			}
+		}
+	}
+
+	component := o.canonicalComponent()
+	component.Schemas = append(component.Schemas, s)
+
+	return nil
+}
+
+// RemoveComponentSchema removes the schema with the given name from any of the components.
+//
+// Returns whether the schema was removed. Refs to the removed schema are reported by Validate.
+func (o *OAS) RemoveComponentSchema(name string) bool {
+	for ci := range o.Components {
+		schemas := o.Components[ci].Schemas
+
+		for i := range schemas {
+			if schemas[i].Name != name {
+				continue
+			}
+
+			o.Invalidate()
+			o.Components[ci].Schemas = append(schemas[:i:i], schemas[i+1:]...)
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// AddSecurityScheme adds the security scheme to the canonical (first) Component.
+//
+// Returns an error if a security scheme with the same name is already defined in any of the components.
+func (o *OAS) AddSecurityScheme(ss SecurityScheme) error {
+	for _, component := range o.Components {
+		for _, existing := range component.SecuritySchemes {
+			if existing.Name == ss.Name {
+				return fmt.Errorf("security scheme %q is already defined", ss.Name)
+			}
+		}
+	}
+
+	component := o.canonicalComponent()
+	component.SecuritySchemes = append(component.SecuritySchemes, ss)
+
+	return nil
+}
+
+// WithResponseRef appends a response referring to the component response with the given name,
+// and returns the path, so that calls can be chained.
+func (p *Path) WithResponseRef(code uint, name string) *Path {
+	p.Responses = append(p.Responses, Response{
+		Code: code,
+		Ref:  ComponentResponseRef(name),
+	})
+
+	return p
+}
+
+// UseSecurity returns the security requirement of the security scheme with the given name, and scopes.
+//
+// The name has to match one of the declared security schemes, which is checked by Validate.
+func UseSecurity(name string, scopes ...string) Security {
+	return Security{
+		AuthName:  name,
+		PermTypes: scopes,
+	}
+}
+
+func (o *OAS) canonicalComponent() *Component {
+	o.Invalidate()
+
+	if len(o.Components) == 0 {
+		o.Components = append(o.Components, Component{})
+	}
+
+	return &o.Components[0]
+}
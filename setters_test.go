@@ -53,6 +53,33 @@ func TestUnitSetOASVersion(t *testing.T) {
 	}
 }
 
+func TestUnitAddServer(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.AddServer("https://api.example.com", "Production").
+		AddServerWithVariables("https://{env}.example.com", "Other environments", ServerVariables{
+			"env": {Default: "staging", Enum: []string{"staging", "dev"}},
+		})
+
+	want := Servers{
+		Server{URL: "https://api.example.com", Description: "Production"},
+		Server{
+			URL:         "https://{env}.example.com",
+			Description: "Other environments",
+			Variables:   ServerVariables{"env": {Default: "staging", Enum: []string{"staging", "dev"}}},
+		},
+	}
+
+	if !reflect.DeepEqual(o.Servers, want) {
+		t.Errorf("got %+v, but want %+v", o.Servers, want)
+	}
+
+	if err := o.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
 func TestUnitSetTag(t *testing.T) {
 	t.Parallel()
 
@@ -72,3 +99,96 @@ func TestUnitSetTag(t *testing.T) {
 		t.Error("tag not set properly")
 	}
 }
+
+func TestUnitAddComponentSchema(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+
+	if err := o.AddComponentSchema(Schema{Name: "User"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := o.AddComponentSchema(Schema{Name: "Tag"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := o.AddComponentSchema(Schema{Name: "User"}); err == nil {
+		t.Error("expected an error for duplicate schema name")
+	}
+
+	if len(o.Components) != 1 || len(o.Components[0].Schemas) != 2 {
+		t.Errorf("expected schemas in a single component, got %+v", o.Components)
+	}
+}
+
+func TestUnitAddSecurityScheme(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Components = Components{Component{}, Component{
+		SecuritySchemes: SecuritySchemes{SecurityScheme{Name: "api_key"}},
+	}}
+
+	if err := o.AddSecurityScheme(SecurityScheme{Name: "api_key"}); err == nil {
+		t.Error("expected an error for duplicate security scheme name")
+	}
+
+	if err := o.AddSecurityScheme(SecurityScheme{Name: "petstore_auth"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if o.Components[0].SecuritySchemes[0].Name != "petstore_auth" {
+		t.Errorf("expected security scheme in the first component, got %+v", o.Components)
+	}
+}
+
+func TestUnitRemoveComponentSchema(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Components = Components{
+		Component{Schemas: Schemas{Schema{Name: "User"}}},
+		Component{Schemas: Schemas{Schema{Name: "Internal"}, Schema{Name: "Order"}}},
+	}
+
+	if !o.RemoveComponentSchema("Internal") {
+		t.Error("expected the schema to be removed")
+	}
+
+	if o.RemoveComponentSchema("Internal") {
+		t.Error("expected nothing to be removed for an unknown schema")
+	}
+
+	if len(o.Components[1].Schemas) != 1 || o.Components[1].Schemas[0].Name != "Order" {
+		t.Errorf("unexpected remaining schemas: %+v", o.Components)
+	}
+}
+
+func TestUnitUseSecurity(t *testing.T) {
+	t.Parallel()
+
+	got := UseSecurity("petstore_auth", "write:pets", "read:pets")
+	want := Security{AuthName: "petstore_auth", PermTypes: []string{"write:pets", "read:pets"}}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, but want %+v", got, want)
+	}
+}
+
+func TestUnitPathWithResponseRef(t *testing.T) {
+	t.Parallel()
+
+	p := Path{Route: "/users/{id}", HTTPMethod: "GET", Responses: Responses{Response{Code: 200, Description: "OK"}}}
+	p.WithResponseRef(404, "NotFound").WithResponseRef(500, "InternalError")
+
+	want := Responses{
+		Response{Code: 200, Description: "OK"},
+		Response{Code: 404, Ref: "#/components/responses/NotFound"},
+		Response{Code: 500, Ref: "#/components/responses/InternalError"},
+	}
+
+	if !reflect.DeepEqual(p.Responses, want) {
+		t.Errorf("got %+v, but want %+v", p.Responses, want)
+	}
+}
@@ -0,0 +1,35 @@
+package docs
+
+import "sort"
+
+// SortTags sorts the tags alphabetically by their names, in place.
+//
+// Tags are emitted in the declaration order by default, so this is meant to be called before BuildDocs
+// when a stable output is preferred, e.g. for golden tests.
+func (o *OAS) SortTags() {
+	o.Invalidate()
+
+	sort.SliceStable(o.Tags, func(i, j int) bool {
+		return o.Tags[i].Name < o.Tags[j].Name
+	})
+}
+
+// SortComponents sorts the schemas and security schemes of every component alphabetically by their names, in place.
+//
+// Only the order of the slices changes, e.g. for iterating them. The built output is the same either way,
+// as component schemas and security schemes are always emitted keyed by their names, in sorted order.
+func (o *OAS) SortComponents() {
+	o.Invalidate()
+
+	for ci := range o.Components {
+		schemas := o.Components[ci].Schemas
+		sort.SliceStable(schemas, func(i, j int) bool {
+			return schemas[i].Name < schemas[j].Name
+		})
+
+		secSchemes := o.Components[ci].SecuritySchemes
+		sort.SliceStable(secSchemes, func(i, j int) bool {
+			return secSchemes[i].Name < secSchemes[j].Name
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package docs
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestUnitSortTags(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Tags = Tags{Tag{Name: "users"}, Tag{Name: "orders"}, Tag{Name: "pets", Description: "first"}, Tag{Name: "pets"}}
+
+	o.SortTags()
+
+	want := []string{"orders", "pets", "pets", "users"}
+	for i, tag := range o.Tags {
+		if tag.Name != want[i] {
+			t.Fatalf("got %+v, but want order %v", o.Tags, want)
+		}
+	}
+
+	if o.Tags[1].Description != "first" {
+		t.Error("expected sorting to be stable")
+	}
+}
+
+func TestUnitSortComponents(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Components = Components{Component{
+		Schemas:         Schemas{Schema{Name: "User"}, Schema{Name: "Address"}, Schema{Name: "Order"}},
+		SecuritySchemes: SecuritySchemes{SecurityScheme{Name: "petstore_auth"}, SecurityScheme{Name: "api_key"}},
+	}}
+
+	unsorted, err := o.BuildDocsBytes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	o.SortComponents()
+
+	sorted, err := o.BuildDocsBytes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !bytes.Equal(unsorted, sorted) {
+		t.Errorf("expected the built output to be unaffected, got:\n%s\nand:\n%s", unsorted, sorted)
+	}
+
+	address, order := bytes.Index(sorted, []byte("Address:")), bytes.Index(sorted, []byte("Order:"))
+	if address < 0 || order < address || bytes.Index(sorted, []byte("User:")) < order {
+		t.Errorf("expected component schemas in sorted order, got:\n%s", sorted)
+	}
+
+	schemas := o.Components[0].Schemas
+	if schemas[0].Name != "Address" || schemas[1].Name != "Order" || schemas[2].Name != "User" {
+		t.Errorf("unexpected schemas order: %+v", schemas)
+	}
+
+	if o.Components[0].SecuritySchemes[0].Name != "api_key" {
+		t.Errorf("unexpected security schemes order: %+v", o.Components[0].SecuritySchemes)
+	}
+}
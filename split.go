@@ -0,0 +1,149 @@
+package docs
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	splitPathsDir           = "paths"
+	splitPathsPointerPrefix = "/" + splitPathsDir + "/"
+	splitDefaultTag         = "default"
+	splitDirFileMode        = 0o755
+)
+
+var splitFileNameRegex = regexp.MustCompile(`[^a-zA-Z0-9_-]+`) //nolint:gochecknoglobals //compiled once.
+
+// buildSplitDocs writes the root output file, and a paths/{tag}.yaml file per tag with the path items
+// referenced from the root one.
+//
+// Every path item is placed in the file of the first tag of its first operation (sorted by method).
+// Path items defined by a ref are kept in the root file as they are.
+func (o *OAS) buildSplitDocs(outPath string, cb ConfigBuilder) error {
+	for _, fragment := range o.rawFragments {
+		if strings.HasPrefix(fragment.pointer, splitPathsPointerPrefix) {
+			return newBuildError(ErrMarshal, fmt.Errorf("raw fragment for %q cannot be applied to split path files",
+				fragment.pointer))
+		}
+	}
+
+	ho := o.transformToHybridOAS(cb)
+	rootRefPrefix := "../" + filepath.Base(outPath)
+
+	files := make(map[string]pathsMap)
+	rootPaths := make(pathsMap, len(ho.Paths))
+
+	for route, methods := range ho.Paths {
+		if _, ok := methods[keyRef]; ok {
+			rootPaths[route] = methods
+
+			continue
+		}
+
+		fileName := splitFileName(methods)
+		if files[fileName] == nil {
+			files[fileName] = make(pathsMap)
+		}
+
+		files[fileName][route] = methods
+		rootPaths[route] = methodsMap{
+			keyRef: fmt.Sprintf("%s/%s.yaml#/%s", splitPathsDir, fileName, escapeJSONPointer(route)),
+		}
+	}
+
+	pathsDir := filepath.Join(filepath.Dir(outPath), splitPathsDir)
+	if err := cb.fs().MkdirAll(pathsDir, splitDirFileMode); err != nil {
+		return newBuildError(ErrWrite, fmt.Errorf("failed creating paths directory: %w", err))
+	}
+
+	for fileName, paths := range files {
+		yml, err := marshalSplitPaths(paths, rootRefPrefix, cb)
+		if err != nil {
+			return newBuildError(ErrMarshal, err)
+		}
+
+		cb.logf("writing paths of %s to %s", fileName, pathsDir)
+
+		err = createYAMLOutFile(cb, filepath.Join(pathsDir, fileName+".yaml"), yml)
+		if err != nil {
+			return newBuildError(ErrWrite, err)
+		}
+	}
+
+	ho.Paths = rootPaths
+
+	yml, err := marshalHybridToYAML(ho, cb)
+	if err != nil {
+		return newBuildError(ErrMarshal, err)
+	}
+
+	err = createYAMLOutFile(cb, outPath, yml)
+	if err != nil {
+		return newBuildError(ErrWrite, err)
+	}
+
+	return nil
+}
+
+func splitFileName(methods methodsMap) string {
+	keys := make([]string, 0, len(methods))
+	for key := range methods {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		op, ok := methods[key].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if tags, _ := op[keyTags].([]string); len(tags) > 0 {
+			return splitFileNameRegex.ReplaceAllString(tags[0], "-")
+		}
+	}
+
+	return splitDefaultTag
+}
+
+// marshalSplitPaths marshals the paths styled like the root file, rewriting local refs so that they point to it.
+//
+// Refs are rewritten on the node tree, so that the order and the style of the marshaled mappings are kept.
+func marshalSplitPaths(paths pathsMap, rootRefPrefix string, cb ConfigBuilder) ([]byte, error) {
+	var node yaml.Node
+
+	err := node.Encode(paths)
+	if err != nil {
+		return nil, fmt.Errorf("failed encoding split paths: %w", err)
+	}
+
+	rewriteLocalRefs(&node, rootRefPrefix)
+
+	return marshalStyledYAML(&node, cb)
+}
+
+func rewriteLocalRefs(node *yaml.Node, prefix string) {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Value == keyRef && value.Kind == yaml.ScalarNode && strings.HasPrefix(value.Value, "#/") {
+				value.Value = prefix + value.Value
+			}
+		}
+	}
+
+	for _, child := range node.Content {
+		rewriteLocalRefs(child, prefix)
+	}
+}
+
+// escapeJSONPointer escapes the token as defined by RFC 6901.
+func escapeJSONPointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
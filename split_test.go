@@ -0,0 +1,130 @@
+package docs
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUnitBuildSplitDocs(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	outPath := filepath.Join(dir, "openapi.yaml")
+
+	o := New()
+	o.Paths = Paths{
+		Path{
+			Route:      "/users",
+			HTTPMethod: "GET",
+			Tags:       []string{"user"},
+			Responses: Responses{Response{
+				Code:    200,
+				Content: ContentTypes{ContentType{Name: "application/json", Schema: "#/components/schemas/User"}},
+			}},
+		},
+		Path{Route: "/pets/{id}", HTTPMethod: "GET", Tags: []string{"pet store"}},
+		Path{Route: "/health", HTTPMethod: "GET"},
+	}
+	o.Components = Components{Component{Schemas: Schemas{Schema{Name: "User"}}}}
+
+	err := o.BuildDocs(ConfigBuilder{CustomPath: outPath, Split: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	root := readFileForTest(t, outPath)
+	for _, want := range []string{"paths/user.yaml#/~1users", "paths/pet-store.yaml#/~1pets~1{id}", "paths/default.yaml#/~1health"} {
+		if !strings.Contains(root, want) {
+			t.Errorf("expected root file to reference %q, got:\n%s", want, root)
+		}
+	}
+
+	users := readFileForTest(t, filepath.Join(dir, splitPathsDir, "user.yaml"))
+	if !strings.Contains(users, "../openapi.yaml#/components/schemas/User") {
+		t.Errorf("expected local refs to point to the root file, got:\n%s", users)
+	}
+}
+
+func TestUnitBuildSplitDocsKeepsOrderAndStyle(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	outPath := filepath.Join(dir, "openapi.yaml")
+
+	o := New()
+	o.Paths = Paths{Path{
+		Route:      "/users",
+		HTTPMethod: "GET",
+		Tags:       []string{"user"},
+		Responses: Responses{Response{Code: 200, Content: ContentTypes{ContentType{
+			Name: "application/json",
+			InlineSchema: &Schema{Type: "object", Properties: Properties{
+				Property{Name: "zeta", Schema: Schema{Type: "string", Enum: []string{"a", "b"}}},
+				Property{Name: "alpha", Schema: Schema{Ref: "#/components/schemas/User"}},
+			}},
+		}}}},
+	}}
+	o.Components = Components{Component{Schemas: Schemas{Schema{Name: "User"}}}}
+
+	if err := o.BuildDocs(ConfigBuilder{CustomPath: outPath, Split: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	users := readFileForTest(t, filepath.Join(dir, splitPathsDir, "user.yaml"))
+
+	zeta, alpha := strings.Index(users, "zeta:"), strings.Index(users, "alpha:")
+	if zeta < 0 || alpha < zeta {
+		t.Errorf("expected properties in their declared order, got:\n%s", users)
+	}
+
+	if !strings.Contains(users, "enum: [a, b]") {
+		t.Errorf("expected the short enum in flow style, got:\n%s", users)
+	}
+
+	if !strings.Contains(users, "../openapi.yaml#/components/schemas/User") {
+		t.Errorf("expected the property ref to point to the root file, got:\n%s", users)
+	}
+
+	if err := o.BuildDocs(ConfigBuilder{CustomPath: outPath, Split: true, ForceBlockStyle: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	users = readFileForTest(t, filepath.Join(dir, splitPathsDir, "user.yaml"))
+	if strings.Contains(users, "[a, b]") || !strings.Contains(users, "../openapi.yaml#/components/schemas/User") {
+		t.Errorf("expected the split paths in block style, got:\n%s", users)
+	}
+}
+
+func readFileForTest(t *testing.T, path string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed reading %s: %v", path, err)
+	}
+
+	return string(content)
+}
+
+func TestUnitBuildSplitDocsPathItemRef(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	outPath := filepath.Join(dir, "openapi.yaml")
+
+	o := New()
+	o.Paths = Paths{Path{Route: "/health", HTTPMethod: "GET"}}
+	o.SetPathItem("/users", PathItem{Ref: "./resources/users.yaml"})
+
+	err := o.BuildDocs(ConfigBuilder{CustomPath: outPath, Split: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	root := readFileForTest(t, outPath)
+	if !strings.Contains(root, "$ref: ./resources/users.yaml") {
+		t.Errorf("expected the path item ref to be kept in the root file, got:\n%s", root)
+	}
+}
@@ -0,0 +1,69 @@
+package docs
+
+import (
+	"runtime/debug"
+	"time"
+)
+
+const (
+	modulePath         = "github.com/Dev22doo/go-oas-docs"
+	generatorName      = "go-oas-docs"
+	develModuleVersion = "(devel)"
+
+	keyGeneratedAt = "x-generated-at"
+	keyGenerator   = "x-generator"
+)
+
+// stampedInfo returns a copy of the info, with the generation timestamp and generator version added to its extensions.
+func stampedInfo(info Info, at time.Time) Info {
+	extensions := make(map[string]interface{}, len(info.Extensions)+2) //nolint:gomnd //stamped extensions.
+	for key, value := range info.Extensions {
+		extensions[key] = value
+	}
+
+	extensions[keyGeneratedAt] = at.UTC().Format(time.RFC3339)
+	extensions[keyGenerator] = generatorName + " " + generatorVersion()
+
+	info.Extensions = extensions
+
+	return info
+}
+
+// generatorVersion returns the version of this module, as recorded in the build info of the binary.
+func generatorVersion() string {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return develModuleVersion
+	}
+
+	return moduleVersion(bi)
+}
+
+// moduleVersion looks this module up in the build info, falling back to "(devel)" when no version is recorded,
+// e.g. for replacements by local directories.
+func moduleVersion(bi *debug.BuildInfo) string {
+	version := ""
+
+	if bi.Main.Path == modulePath {
+		version = bi.Main.Version
+	}
+
+	for _, dep := range bi.Deps {
+		if dep.Path != modulePath {
+			continue
+		}
+
+		version = dep.Version
+		if dep.Replace != nil {
+			version = dep.Replace.Version
+		}
+
+		break
+	}
+
+	if isStrEmpty(version) {
+		return develModuleVersion
+	}
+
+	return version
+}
@@ -0,0 +1,88 @@
+package docs
+
+import (
+	"runtime/debug"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUnitStampedInfo(t *testing.T) {
+	t.Parallel()
+
+	info := Info{Title: "Stamped", Extensions: map[string]interface{}{"x-logo": "logo.png"}}
+	at := time.Date(2021, 3, 4, 5, 6, 7, 0, time.FixedZone("CET", 3600))
+
+	got := stampedInfo(info, at)
+
+	if got.Extensions[keyGeneratedAt] != "2021-03-04T04:06:07Z" {
+		t.Errorf("unexpected timestamp: %v", got.Extensions[keyGeneratedAt])
+	}
+
+	if generator, _ := got.Extensions[keyGenerator].(string); !strings.HasPrefix(generator, "go-oas-docs ") {
+		t.Errorf("unexpected generator: %v", got.Extensions[keyGenerator])
+	}
+
+	if got.Extensions["x-logo"] != "logo.png" || len(info.Extensions) != 1 {
+		t.Errorf("expected existing extensions to be kept without modifying the original, got %v", info.Extensions)
+	}
+}
+
+func TestUnitBuildDocsStampBuildInfo(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+
+	yml, err := o.BuildDocsBytes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Contains(string(yml), keyGeneratedAt) {
+		t.Error("expected no stamp by default")
+	}
+
+	yml, err = o.BuildDocsBytes(ConfigBuilder{StampBuildInfo: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, key := range []string{keyGeneratedAt, keyGenerator} {
+		if !strings.Contains(string(yml), "    "+key+": ") {
+			t.Errorf("expected %s under info, got:\n%s", key, yml)
+		}
+	}
+}
+
+func TestUnitModuleVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		bi   debug.BuildInfo
+		want string
+	}{
+		{name: "main module", bi: debug.BuildInfo{Main: debug.Module{Path: modulePath, Version: "v1.2.3"}}, want: "v1.2.3"},
+		{name: "dependency", bi: debug.BuildInfo{
+			Deps: []*debug.Module{{Path: modulePath, Version: "v1.2.3"}},
+		}, want: "v1.2.3"},
+		{name: "replaced by a version", bi: debug.BuildInfo{Deps: []*debug.Module{{
+			Path: modulePath, Version: "v1.2.3", Replace: &debug.Module{Path: "example.com/fork", Version: "v1.2.4"},
+		}}}, want: "v1.2.4"},
+		{name: "replaced by a directory", bi: debug.BuildInfo{Deps: []*debug.Module{{
+			Path: modulePath, Version: "v1.2.3", Replace: &debug.Module{Path: "../go-oas-docs"},
+		}}}, want: develModuleVersion},
+		{name: "not found", bi: debug.BuildInfo{}, want: develModuleVersion},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := moduleVersion(&tt.bi); got != tt.want {
+				t.Errorf("got %q, but want %q", got, tt.want)
+			}
+		})
+	}
+}
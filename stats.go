@@ -0,0 +1,67 @@
+package docs
+
+// DocStats represents documentation quality counts of the OAS, e.g. for a scorecard.
+type DocStats struct {
+	Operations             int
+	OperationsWithSummary  int
+	OperationsWithExamples int // Having examples in the request body or any of the responses.
+	Responses              int
+	ResponsesWithoutSchema int // Having content, none of which has a schema. Responses by ref are not counted.
+}
+
+// OperationCount returns the number of operations, i.e. of the Paths.
+func (o *OAS) OperationCount() int {
+	return len(o.Paths)
+}
+
+// Stats returns the documentation quality counts of the operations, including the default responses.
+//
+// Registered routes are not called, so make sure that Paths are already in their final state.
+func (o *OAS) Stats() DocStats {
+	stats := DocStats{Operations: o.OperationCount()}
+
+	for _, path := range o.pathsWithDefaults() { //nolint:gocritic //consider indexing?
+		if !isStrEmpty(path.Summary) {
+			stats.OperationsWithSummary++
+		}
+
+		hasExamples := path.RequestBody.Content.hasExamples()
+
+		for i := range path.Responses {
+			stats.Responses++
+
+			resp := &path.Responses[i]
+			hasExamples = hasExamples || resp.Content.hasExamples()
+
+			if isStrEmpty(resp.Ref) && len(resp.Content) > 0 && !resp.Content.hasSchema() {
+				stats.ResponsesWithoutSchema++
+			}
+		}
+
+		if hasExamples {
+			stats.OperationsWithExamples++
+		}
+	}
+
+	return stats
+}
+
+func (cts ContentTypes) hasExamples() bool {
+	for _, ct := range cts {
+		if len(ct.Examples) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (cts ContentTypes) hasSchema() bool {
+	for _, ct := range cts {
+		if !isStrEmpty(ct.Schema) || ct.InlineSchema != nil {
+			return true
+		}
+	}
+
+	return false
+}
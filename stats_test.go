@@ -0,0 +1,46 @@
+package docs
+
+import "testing"
+
+func TestUnitStats(t *testing.T) {
+	t.Parallel()
+
+	jsonUser := ContentTypes{ContentType{Name: "application/json", Schema: "#/components/schemas/User"}}
+
+	o := New()
+	o.DefaultResponses = Responses{Response{CodeRange: ResponseCodeDefault, Ref: ComponentResponseRef("Error")}}
+	o.Paths = Paths{
+		Path{
+			Route: "/users", HTTPMethod: "GET", Summary: "List users",
+			Responses: Responses{Response{Code: 200, Content: jsonUser}},
+		},
+		Path{
+			Route: "/users", HTTPMethod: "POST",
+			RequestBody: RequestBody{Content: ContentTypes{ContentType{
+				Name:     "application/json",
+				Schema:   "#/components/schemas/User",
+				Examples: Examples{"new": Example{Value: map[string]string{"name": "Jane"}}},
+			}}},
+			Responses: Responses{
+				Response{Code: 201, Content: ContentTypes{ContentType{Name: "application/json"}}},
+				Response{Code: 204},
+			},
+		},
+	}
+
+	want := DocStats{
+		Operations:             2,
+		OperationsWithSummary:  1,
+		OperationsWithExamples: 1,
+		Responses:              5,
+		ResponsesWithoutSchema: 1,
+	}
+
+	if got := o.Stats(); got != want {
+		t.Errorf("got %+v, but want %+v", got, want)
+	}
+
+	if got := o.OperationCount(); got != 2 {
+		t.Errorf("got %d operations, but want 2", got)
+	}
+}
@@ -0,0 +1,107 @@
+package docs
+
+import (
+	"bytes"
+	_ "embed" // Required for embedding the meta-schemas.
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"gopkg.in/yaml.v3"
+)
+
+// Official OAS JSON Schemas, as published on https://spec.openapis.org/#openapi-specification-schemas.
+var (
+	//go:embed schemas/oas-3.0.json
+	oas30MetaSchema string //nolint:gochecknoglobals //embedded.
+
+	//go:embed schemas/oas-3.1.json
+	oas31MetaSchema string //nolint:gochecknoglobals //embedded.
+)
+
+const (
+	oas30MetaSchemaURL = "https://spec.openapis.org/oas/3.0/schema/2021-09-28"
+	oas31MetaSchemaURL = "https://spec.openapis.org/oas/3.1/schema/2022-10-07"
+	oasVersion30Prefix = "3.0"
+)
+
+// ValidateStrict builds the docs and validates the output against the official OAS JSON Schema
+// of the declared version, in addition to the checks done by Validate.
+//
+// This catches structural issues which are not covered by Validate, at the cost of building the docs.
+// The docs are always rebuilt, bypassing the output cached by BuildDocsBytes.
+func (o *OAS) ValidateStrict() error {
+	url, metaSchema, err := o.OASVersion.metaSchema()
+	if err != nil {
+		return err
+	}
+
+	yml, err := o.buildDocsBytes(ConfigBuilder{})
+	if err != nil {
+		return err
+	}
+
+	doc, err := yamlToJSONValue(yml)
+	if err != nil {
+		return fmt.Errorf("failed converting docs for validation: %w", err)
+	}
+
+	compiler := jsonschema.NewCompiler()
+
+	err = compiler.AddResource(url, strings.NewReader(metaSchema))
+	if err != nil {
+		return fmt.Errorf("failed loading OAS %s schema: %w", o.OASVersion, err)
+	}
+
+	schema, err := compiler.Compile(url)
+	if err != nil {
+		return fmt.Errorf("failed compiling OAS %s schema: %w", o.OASVersion, err)
+	}
+
+	err = schema.Validate(doc)
+	if err != nil {
+		return fmt.Errorf("docs do not conform to the OAS %s schema: %w", o.OASVersion, err)
+	}
+
+	return nil
+}
+
+// metaSchema returns the URL and content of the official JSON Schema for the version.
+func (ver OASVersion) metaSchema() (string, string, error) {
+	switch {
+	case ver.is31():
+		return oas31MetaSchemaURL, oas31MetaSchema, nil
+	case strings.HasPrefix(string(ver), oasVersion30Prefix):
+		return oas30MetaSchemaURL, oas30MetaSchema, nil
+	default:
+		return emptyStr, emptyStr, fmt.Errorf("no OAS schema available for version %q", ver)
+	}
+}
+
+// yamlToJSONValue converts YAML to a value as decoded from JSON, which is expected by the schema validator.
+func yamlToJSONValue(yml []byte) (interface{}, error) {
+	var generic interface{}
+
+	err := yaml.Unmarshal(yml, &generic)
+	if err != nil {
+		return nil, fmt.Errorf("failed unmarshaling YAML: %w", err)
+	}
+
+	jsn, err := json.Marshal(generic)
+	if err != nil {
+		return nil, fmt.Errorf("failed marshaling JSON: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(jsn))
+	decoder.UseNumber()
+
+	var value interface{}
+
+	err = decoder.Decode(&value)
+	if err != nil {
+		return nil, fmt.Errorf("failed unmarshaling JSON: %w", err)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,106 @@
+package docs
+
+import "testing"
+
+func TestUnitValidateStrict(t *testing.T) {
+	t.Parallel()
+
+	validOAS := func(ver string) OAS {
+		o := New()
+		o.SetOASVersion(ver)
+		o.Info.Title = "Strict"
+		o.Info.Version = "1.0.0"
+		o.Info.SetContact("api@example.com")
+		o.Paths = Paths{Path{
+			Route:       "/users",
+			HTTPMethod:  "GET",
+			OperationID: "getUsers",
+			Responses:   Responses{Response{Code: 200, Description: "OK"}},
+		}}
+
+		return o
+	}
+
+	tests := []struct {
+		name    string
+		oas     func() OAS
+		wantErr bool
+	}{
+		{
+			name: "valid 3.0",
+			oas:  func() OAS { return validOAS("3.0.3") },
+		},
+		{
+			name: "valid 3.1",
+			oas:  func() OAS { return validOAS("3.1.0") },
+		},
+		{
+			name: "invalid schema type",
+			oas: func() OAS {
+				o := validOAS("3.0.3")
+				_ = o.AddComponentSchema(Schema{Name: "Status", Type: "enum"})
+
+				return o
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid contact email",
+			oas: func() OAS {
+				o := validOAS("3.0.3")
+				o.Info.SetContact("api")
+
+				return o
+			},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported version",
+			oas:     func() OAS { return validOAS("2.0") },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			o := tt.oas()
+
+			err := o.ValidateStrict()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateStrict() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUnitValidateStrictUncached(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.OASVersion = "3.0.3"
+	o.Info.Title = "Strict"
+	o.Info.Version = "1.0.0"
+	o.Info.SetContact("api@example.com")
+	o.Paths = Paths{Path{Route: "/users", HTTPMethod: "GET", Responses: Responses{Response{Code: 200, Description: "OK"}}}}
+
+	if err := o.ValidateStrict(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if o.cache.valid {
+		t.Error("expected strict validation to bypass the cache")
+	}
+
+	if _, err := o.BuildDocsBytes(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	o.Paths[0].Responses = nil
+
+	if err := o.ValidateStrict(); err == nil {
+		t.Error("expected an error for the operation without responses")
+	}
+}
@@ -0,0 +1,6 @@
+package health
+
+import "net/http"
+
+// @route GET /healthz
+func Healthz(w http.ResponseWriter, r *http.Request) {}
@@ -0,0 +1,20 @@
+package handlers
+
+import "net/http"
+
+// GetUser returns the user by its id.
+//
+// @route GET /users/{id}
+// @summary Returns the user.
+// @tag users
+func GetUser(w http.ResponseWriter, r *http.Request) {}
+
+// CreateUser is documented by the route annotation only.
+//
+// @route post /users
+// @tag users
+// @tag admin
+func CreateUser(w http.ResponseWriter, r *http.Request) {}
+
+// notAHandler has no route annotation, so it is skipped.
+func notAHandler() {}
@@ -0,0 +1,4 @@
+package handlers_malformed
+
+// @route /users
+func ListUsers() {}
@@ -0,0 +1,930 @@
+package docs
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	extensionPrefix = "x-"
+	paramInPath     = "path"
+	paramInQuery    = "query"
+
+	securityTypeOAuth2        = "oauth2"
+	securityTypeOpenIDConnect = "openIdConnect"
+
+	mediaTypeMultipartPrefix = "multipart/"
+	mediaTypeFormURLEncoded  = "application/x-www-form-urlencoded"
+)
+
+var (
+	serverURLVariableRegex = regexp.MustCompile(`{([^{}]+)}`) //nolint:gochecknoglobals //compiled once.
+	responseCodeRangeRegex = regexp.MustCompile(`^[1-5]XX$`)  //nolint:gochecknoglobals //compiled once.
+	mediaTypeRegex         = regexp.MustCompile(              //nolint:gochecknoglobals //compiled once.
+		`^(?:\*/\*|[A-Za-z0-9][\w!#$&^.+-]*/(?:\*|[A-Za-z0-9][\w!#$&^.+-]*))(?:\s*;.*)?$`)
+)
+
+//nolint:gochecknoglobals //lookup table.
+var serverURLSchemes = map[string]bool{"http": true, "https": true, "ws": true, "wss": true}
+
+//nolint:gochecknoglobals //lookup table.
+var encodingStyles = map[string]bool{"form": true, "spaceDelimited": true, "pipeDelimited": true, "deepObject": true}
+
+//nolint:gochecknoglobals //lookup table.
+var registeredMediaTypes = map[string]bool{
+	"*": true, "application": true, "audio": true, "example": true, "font": true, "image": true,
+	"message": true, "model": true, "multipart": true, "text": true, "video": true,
+}
+
+type validatorFn func(o *OAS) error
+
+// ValidateRefsResolve checks that every internal ref resolves, including the ones between component schemas,
+// e.g. from properties, items or compositions. External refs are not checked.
+//
+// It is a subset of Validate, returning the first unresolved ref found.
+func (o *OAS) ValidateRefsResolve() error {
+	for _, validator := range []validatorFn{validateSchemaRefs, validateResponseRefs} {
+		if err := validator(o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validate checks the OAS structure for issues which would result in an invalid specification.
+//
+// It is called by BuildDocs, but can be used on its own as well. Returns the first issue found.
+func (o *OAS) Validate() error {
+	validators := []validatorFn{
+		validateInfo,
+		validateServers,
+		validatePathItemRefs,
+		validateHTTPMethods,
+		validateOperationIDs,
+		validateParameters,
+		validateSchemaRefs,
+		validateProperties,
+		validateNullableTypes,
+		validateDiscriminators,
+		validateSecuritySchemes,
+		validateSecurityNames,
+		validateExamples,
+		validateResponseCodes,
+		validateResponseRefs,
+		validateMediaTypes,
+	}
+
+	for _, validator := range validators {
+		if err := validator(o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateProperties checks that no schema declares several properties of the same name,
+// as these would overwrite each other in the output.
+func validateProperties(o *OAS) error {
+	return o.walkSchemas(func(s *Schema) error {
+		declared := make(map[string]bool, len(s.Properties))
+
+		for _, prop := range s.Properties {
+			if declared[prop.Name] {
+				return fmt.Errorf("property %q is declared more than once", prop.Name)
+			}
+
+			declared[prop.Name] = true
+		}
+
+		return nil
+	})
+}
+
+// validateNullableTypes checks that nullable schemas declare a type for OAS 3.1,
+// as these are emitted as type arrays with null.
+func validateNullableTypes(o *OAS) error {
+	if !o.OASVersion.is31() {
+		return nil
+	}
+
+	return o.checkNullableTypes()
+}
+
+func validateInfo(o *OAS) error {
+	if err := validateExtensionKeys(o.Info.Extensions); err != nil {
+		return fmt.Errorf("info: %w", err)
+	}
+
+	if !isStrEmpty(string(o.Info.License.URL)) && !isStrEmpty(o.Info.License.Identifier) {
+		return fmt.Errorf("info: license: only one of url or identifier can be set")
+	}
+
+	return nil
+}
+
+func validateExtensionKeys(extensions map[string]interface{}) error {
+	keys := make([]string, 0, len(extensions))
+	for key := range extensions {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if !strings.HasPrefix(key, extensionPrefix) {
+			return fmt.Errorf("extension key %q must start with %q", key, extensionPrefix)
+		}
+	}
+
+	return nil
+}
+
+func validatePathItemRefs(o *OAS) error {
+	for i := range o.Paths {
+		if item, ok := o.PathItems[o.Paths[i].Route]; ok && !isStrEmpty(item.Ref) {
+			return fmt.Errorf("%s %s: route is defined by the path item ref %q, so it cannot have operations",
+				o.Paths[i].HTTPMethod, o.Paths[i].Route, item.Ref)
+		}
+	}
+
+	return nil
+}
+
+func validateServers(o *OAS) error {
+	if err := o.Servers.validate(); err != nil {
+		return err
+	}
+
+	routes := make([]string, 0, len(o.PathItems))
+	for route := range o.PathItems {
+		routes = append(routes, route)
+	}
+
+	sort.Strings(routes)
+
+	for _, route := range routes {
+		if err := o.PathItems[route].Servers.validate(); err != nil {
+			return fmt.Errorf("%s: %w", route, err)
+		}
+	}
+
+	for i := range o.Paths {
+		if err := o.Paths[i].Servers.validate(); err != nil {
+			return fmt.Errorf("%s %s: %w", o.Paths[i].HTTPMethod, o.Paths[i].Route, err)
+		}
+	}
+
+	return nil
+}
+
+func (ss Servers) validate() error {
+	for _, server := range ss {
+		if err := server.validate(); err != nil {
+			return fmt.Errorf("server %s: %w", server.URL, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) validate() error {
+	if err := s.URL.validateServerURL(); err != nil {
+		return err
+	}
+
+	if err := validateExtensionKeys(s.Extensions); err != nil {
+		return err
+	}
+
+	for _, match := range serverURLVariableRegex.FindAllStringSubmatch(string(s.URL), -1) {
+		if _, ok := s.Variables[match[1]]; !ok {
+			return fmt.Errorf("variable %q used in URL is not defined", match[1])
+		}
+	}
+
+	names := make([]string, 0, len(s.Variables))
+	for name := range s.Variables {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := s.Variables[name].validate(); err != nil {
+			return fmt.Errorf("variable %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// validateServerURL checks if the server URL is either relative, or absolute with a known scheme and a host.
+// Variables in curly braces are substituted before parsing, so templated URLs are allowed.
+func (u URL) validateServerURL() error {
+	if isStrEmpty(string(u)) {
+		return fmt.Errorf("URL is required")
+	}
+
+	parsed, err := url.Parse(serverURLVariableRegex.ReplaceAllString(string(u), "1"))
+	if err != nil {
+		return fmt.Errorf("malformed URL: %w", err)
+	}
+
+	if isStrEmpty(parsed.Scheme) {
+		return nil
+	}
+
+	if !serverURLSchemes[strings.ToLower(parsed.Scheme)] {
+		return fmt.Errorf("unsupported URL scheme %q", parsed.Scheme)
+	}
+
+	if isStrEmpty(parsed.Host) {
+		return fmt.Errorf("URL host is required")
+	}
+
+	return nil
+}
+
+func (sv ServerVariable) validate() error {
+	if isStrEmpty(sv.Default) {
+		return fmt.Errorf("default value is required")
+	}
+
+	if len(sv.Enum) == 0 {
+		return nil
+	}
+
+	for _, e := range sv.Enum {
+		if e == sv.Default {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("default value %q is not one of the enum values %v", sv.Default, sv.Enum)
+}
+
+func validateHTTPMethods(o *OAS) error {
+	for _, path := range o.Paths { //nolint:gocritic //consider indexing?
+		if !isValidHTTPMethod(path.HTTPMethod) {
+			return fmt.Errorf("%s: invalid HTTP method %q", path.Route, path.HTTPMethod)
+		}
+	}
+
+	for _, component := range o.Components {
+		for _, name := range component.PathItems.names() {
+			for _, op := range component.PathItems[name].Operations { //nolint:gocritic //consider indexing?
+				if !isValidHTTPMethod(op.HTTPMethod) {
+					return fmt.Errorf("component path item %s: invalid HTTP method %q", name, op.HTTPMethod)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// isValidHTTPMethod checks if the method is one of the methods supported by the OAS path item, case insensitive.
+func isValidHTTPMethod(method string) bool {
+	switch strings.ToLower(method) {
+	case "get", "put", "post", "delete", "options", "head", "patch", "trace":
+		return true
+	default:
+		return false
+	}
+}
+
+// validateOperationIDPattern checks the operationIds against the configured pattern, reporting all violations.
+func (o *OAS) validateOperationIDPattern(cb ConfigBuilder) error {
+	if cb.OperationIDPattern == nil {
+		return nil
+	}
+
+	var violations []string
+
+	for i := range o.Paths {
+		operationID := cb.OperationIDCase.apply(o.Paths[i].OperationID)
+		if isStrEmpty(operationID) || cb.OperationIDPattern.MatchString(operationID) {
+			continue
+		}
+
+		violations = append(violations, fmt.Sprintf("%q (%s %s)", operationID, o.Paths[i].HTTPMethod, o.Paths[i].Route))
+	}
+
+	if len(violations) > 0 {
+		return fmt.Errorf("operationIds not matching %s: %s", cb.OperationIDPattern, strings.Join(violations, ", "))
+	}
+
+	return nil
+}
+
+// validateNormalizedRoutes checks that no operations of distinct routes with the same method collide
+// after applying TrailingSlash, e.g. GET /users and GET /users/ with TrailingSlashStrip,
+// as one of them would overwrite the other. Operations with different methods are merged.
+func (o *OAS) validateNormalizedRoutes(cb ConfigBuilder) error {
+	if cb.TrailingSlash == TrailingSlashUntouched {
+		return nil
+	}
+
+	originalRoutes := make(map[string]string, len(o.Paths))
+
+	for i := range o.Paths {
+		if cb.isExcluded(&o.Paths[i]) {
+			continue
+		}
+
+		method := strings.ToUpper(o.Paths[i].HTTPMethod)
+		key := method + " " + cb.TrailingSlash.normalize(o.Paths[i].Route)
+
+		if original, ok := originalRoutes[key]; ok && original != o.Paths[i].Route {
+			return fmt.Errorf("%s operations of routes %q and %q collide after trailing slash normalization",
+				method, original, o.Paths[i].Route)
+		}
+
+		originalRoutes[key] = o.Paths[i].Route
+	}
+
+	return nil
+}
+
+// validateCasedOperationIDs checks that the operationIds are still unique after applying OperationIDCase,
+// e.g. get_user and get-user both result in getUser.
+func (o *OAS) validateCasedOperationIDs(cb ConfigBuilder) error {
+	if cb.OperationIDCase == OperationIDAsIs {
+		return nil
+	}
+
+	seen := make(map[string]string, len(o.Paths))
+
+	for i := range o.Paths {
+		operationID := cb.OperationIDCase.apply(o.Paths[i].OperationID)
+		if isStrEmpty(operationID) {
+			continue
+		}
+
+		if original, ok := seen[operationID]; ok {
+			return fmt.Errorf("%s %s: operationId %q results in duplicate %q, as does %q",
+				o.Paths[i].HTTPMethod, o.Paths[i].Route, o.Paths[i].OperationID, operationID, original)
+		}
+
+		seen[operationID] = o.Paths[i].OperationID
+	}
+
+	return nil
+}
+
+func validateOperationIDs(o *OAS) error {
+	seen := make(map[string]bool, len(o.Paths))
+
+	for _, path := range o.Paths { //nolint:gocritic //consider indexing?
+		if isStrEmpty(path.OperationID) {
+			continue
+		}
+
+		if seen[path.OperationID] {
+			return fmt.Errorf("%s %s: duplicate operationId %q", path.HTTPMethod, path.Route, path.OperationID)
+		}
+
+		seen[path.OperationID] = true
+	}
+
+	return nil
+}
+
+// validateSchemaRefs checks if all component schema refs used by paths point to existing schemas.
+func validateSchemaRefs(o *OAS) error {
+	schemas := o.componentSchemasByName()
+
+	for i := range o.Paths {
+		for _, ref := range o.Paths[i].schemaRefs() {
+			name, ok := schemaNameFromRef(ref)
+			if !ok {
+				continue
+			}
+
+			if _, exists := schemas[name]; !exists {
+				return fmt.Errorf("%s %s: referenced schema %q is not defined",
+					o.Paths[i].HTTPMethod, o.Paths[i].Route, ref)
+			}
+		}
+	}
+
+	for _, component := range o.Components {
+		for si := range component.Schemas {
+			for _, ref := range component.Schemas[si].schemaRefs() {
+				if refName, ok := schemaNameFromRef(ref); ok && schemas[refName] == nil {
+					return fmt.Errorf("component schema %s: referenced schema %q is not defined",
+						component.Schemas[si].Name, ref)
+				}
+			}
+		}
+
+		for _, name := range component.PathItems.names() {
+			item := component.PathItems[name]
+			for _, ref := range item.schemaRefs() {
+				if refName, ok := schemaNameFromRef(ref); ok && schemas[refName] == nil {
+					return fmt.Errorf("component path item %s: referenced schema %q is not defined", name, ref)
+				}
+			}
+		}
+
+		for name, resp := range component.Responses {
+			for _, ref := range resp.schemaRefs() {
+				if refName, ok := schemaNameFromRef(ref); ok && schemas[refName] == nil {
+					return fmt.Errorf("component response %s: referenced schema %q is not defined", name, ref)
+				}
+			}
+		}
+	}
+
+	for i := range o.DefaultResponses {
+		for _, ref := range o.DefaultResponses[i].schemaRefs() {
+			name, ok := schemaNameFromRef(ref)
+			if !ok {
+				continue
+			}
+
+			if _, exists := schemas[name]; !exists {
+				return fmt.Errorf("default response %s: referenced schema %q is not defined",
+					o.DefaultResponses[i].key(), ref)
+			}
+		}
+	}
+
+	return nil
+}
+
+// contents returns all content types used by the path.
+func (p *Path) contents() []ContentTypes {
+	contents := []ContentTypes{p.RequestBody.Content}
+
+	for _, param := range p.Parameters {
+		contents = append(contents, param.Content)
+	}
+
+	for _, resp := range p.Responses {
+		contents = append(contents, resp.Content)
+	}
+
+	return contents
+}
+
+func validateSecuritySchemes(o *OAS) error {
+	for _, component := range o.Components {
+		for _, ss := range component.SecuritySchemes { //nolint:gocritic //consider indexing?
+			if ss.Type != securityTypeOpenIDConnect {
+				continue
+			}
+
+			if isStrEmpty(string(ss.OpenIDConnectURL)) {
+				return fmt.Errorf("security scheme %q: openIdConnectUrl is required for %s type", ss.Name, ss.Type)
+			}
+
+			if u, err := url.Parse(string(ss.OpenIDConnectURL)); err != nil || !u.IsAbs() {
+				return fmt.Errorf("security scheme %q: openIdConnectUrl %q is not an absolute URL",
+					ss.Name, ss.OpenIDConnectURL)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateSecurityNames checks if all security requirements used by paths refer to declared security schemes,
+// requesting only the scopes declared by oauth2 schemes.
+func validateSecurityNames(o *OAS) error {
+	declared := make(map[string]*SecurityScheme)
+
+	for ci := range o.Components {
+		for si := range o.Components[ci].SecuritySchemes {
+			ss := &o.Components[ci].SecuritySchemes[si]
+			declared[ss.Name] = ss
+		}
+	}
+
+	for _, path := range o.Paths { //nolint:gocritic //consider indexing?
+		for _, sec := range path.Security {
+			ss, ok := declared[sec.AuthName]
+			if !ok {
+				return fmt.Errorf("%s %s: security scheme %q is not declared", path.HTTPMethod, path.Route, sec.AuthName)
+			}
+
+			if err := ss.validateRequestedScopes(sec.PermTypes, o.OASVersion); err != nil {
+				return fmt.Errorf("%s %s: security scheme %q: %w", path.HTTPMethod, path.Route, sec.AuthName, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateRequestedScopes checks if the scopes are declared by any of the oauth2 flows.
+// Schemes of other types can not request scopes, except for openIdConnect and OAS 3.1 role names.
+func (ss *SecurityScheme) validateRequestedScopes(scopes []string, ver OASVersion) error {
+	if ss.Type != securityTypeOAuth2 {
+		if len(scopes) > 0 && ss.Type != securityTypeOpenIDConnect && !ver.is31() {
+			return fmt.Errorf("scopes can be requested only for %s and %s schemes",
+				securityTypeOAuth2, securityTypeOpenIDConnect)
+		}
+
+		return nil
+	}
+
+	available := make(map[string]bool)
+
+	for _, flow := range ss.Flows {
+		for _, scope := range flow.Scopes {
+			available[scope.Name] = true
+		}
+	}
+
+	for _, scope := range scopes {
+		if !available[scope] {
+			return fmt.Errorf("scope %q is not declared by any of the flows", scope)
+		}
+	}
+
+	return nil
+}
+
+// validateDiscriminators checks if every oneOf and anyOf subschema of the schemas with a discriminator,
+// including inline ones, requires the discriminator property, either directly or through its allOf schemas.
+func validateDiscriminators(o *OAS) error {
+	schemas := o.componentSchemasByName()
+
+	return o.walkSchemas(func(s *Schema) error {
+		if s.Discriminator == nil {
+			return nil
+		}
+
+		var offending []string
+
+		for _, composition := range []struct {
+			keyword    string
+			subSchemas Schemas
+		}{{keyOneOf, s.OneOf}, {keyAnyOf, s.AnyOf}} {
+			keyword, subSchemas := composition.keyword, composition.subSchemas
+			for i := range subSchemas {
+				if requiresProperty(&subSchemas[i], s.Discriminator.PropertyName, schemas, make(map[string]bool)) {
+					continue
+				}
+
+				name, ok := schemaNameFromRef(subSchemas[i].Ref)
+				if !ok {
+					name = fmt.Sprintf("%s[%d]", keyword, i)
+				}
+
+				offending = append(offending, name)
+			}
+		}
+
+		if len(offending) > 0 {
+			return fmt.Errorf("discriminator property %q is not required by subschemas %s",
+				s.Discriminator.PropertyName, strings.Join(offending, ", "))
+		}
+
+		return nil
+	})
+}
+
+// requiresProperty returns whether the schema, or any of its allOf schemas, lists the property as a required one.
+func requiresProperty(s *Schema, property string, schemas map[string]*Schema, seen map[string]bool) bool {
+	if refName, ok := schemaNameFromRef(s.Ref); ok {
+		if seen[refName] || schemas[refName] == nil {
+			return false
+		}
+
+		seen[refName] = true
+		s = schemas[refName]
+	}
+
+	for _, required := range s.Required {
+		if required == property {
+			return true
+		}
+	}
+
+	for i := range s.AllOf {
+		if requiresProperty(&s.AllOf[i], property, schemas, seen) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func validateExamples(o *OAS) error {
+	for _, path := range o.Paths { //nolint:gocritic //consider indexing?
+		for _, content := range path.contents() {
+			if err := content.validateExamples(); err != nil {
+				return fmt.Errorf("%s %s: %w", path.HTTPMethod, path.Route, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (cts ContentTypes) validateExamples() error {
+	for _, ct := range cts {
+		for name, example := range ct.Examples {
+			if example.Value != nil && !isStrEmpty(string(example.ExternalValue)) {
+				return fmt.Errorf("%s example %q: only one of value or externalValue can be set", ct.Name, name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateResponseCodes(o *OAS) error {
+	if err := o.DefaultResponses.validateCodes(); err != nil {
+		return fmt.Errorf("default responses: %w", err)
+	}
+
+	for _, path := range o.Paths { //nolint:gocritic //consider indexing?
+		if err := path.Responses.validateCodes(); err != nil {
+			return fmt.Errorf("%s %s: %w", path.HTTPMethod, path.Route, err)
+		}
+	}
+
+	return nil
+}
+
+// validateCodes checks the codes of the responses, which have to be unique, as these are emitted as keys.
+func (rs Responses) validateCodes() error {
+	seen := make(map[string]bool, len(rs))
+
+	for _, resp := range rs { //nolint:gocritic //consider indexing?
+		if err := resp.validateCode(); err != nil {
+			return err
+		}
+
+		if seen[resp.key()] {
+			return fmt.Errorf("response %s is defined more than once", resp.key())
+		}
+
+		seen[resp.key()] = true
+	}
+
+	return nil
+}
+
+// validateMediaTypes checks if all content type names are well formed type/subtype media types.
+func validateMediaTypes(o *OAS) error {
+	for _, lc := range o.locatedContents() {
+		if err := lc.content.validateMediaTypes(o.defaultMediaType()); err != nil {
+			return fmt.Errorf("%s: %w", lc.location, err)
+		}
+	}
+
+	return nil
+}
+
+// locatedContent represents the content types of a request body, parameter or response,
+// along with the location of their owner used by the reported issues.
+type locatedContent struct {
+	location string
+	content  ContentTypes
+}
+
+// locatedContents returns the content types of the operations, the default responses and the components,
+// the latter in the order of their sorted names.
+func (o *OAS) locatedContents() []locatedContent {
+	var contents []locatedContent
+
+	for _, path := range o.Paths { //nolint:gocritic //consider indexing?
+		for _, content := range path.contents() {
+			contents = append(contents, locatedContent{path.HTTPMethod + " " + path.Route, content})
+		}
+	}
+
+	for i := range o.DefaultResponses {
+		contents = append(contents, locatedContent{
+			"default response " + o.DefaultResponses[i].key(), o.DefaultResponses[i].Content,
+		})
+	}
+
+	for _, component := range o.Components {
+		names := make([]string, 0, len(component.Responses))
+		for name := range component.Responses {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		for _, name := range names {
+			contents = append(contents, locatedContent{"component response " + name, component.Responses[name].Content})
+		}
+
+		for _, name := range component.PathItems.names() {
+			item := component.PathItems[name]
+			for i := range item.Operations {
+				for _, content := range item.Operations[i].contents() {
+					contents = append(contents, locatedContent{"component path item " + name, content})
+				}
+			}
+		}
+	}
+
+	return contents
+}
+
+// validateMediaTypes validates the content types, where the ones with a schema but no name are named
+// by the default media type, as done by the build.
+func (cts ContentTypes) validateMediaTypes(defaultName string) error {
+	for _, ct := range cts {
+		if isStrEmpty(ct.Name) && ct.hasSchema() {
+			ct.Name = defaultName
+		}
+
+		if isStrEmpty(ct.Name) {
+			return fmt.Errorf("media type is required for content without a schema")
+		}
+
+		if !mediaTypeRegex.MatchString(ct.Name) {
+			return fmt.Errorf("malformed media type %q", ct.Name)
+		}
+
+		if ct.InlineSchema != nil && !isStrEmpty(ct.Schema) {
+			return fmt.Errorf("%s: only one of schema or inline schema can be set", ct.Name)
+		}
+
+		if len(ct.Encoding) > 0 && !ct.supportsEncoding() {
+			return fmt.Errorf("encoding is supported only by multipart and %s media types, not by %q",
+				mediaTypeFormURLEncoded, ct.Name)
+		}
+
+		if err := validateEncodingStyles(ct.Encoding); err != nil {
+			return fmt.Errorf("%s: %w", ct.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func validateEncodingStyles(encoding map[string]Encoding) error {
+	properties := make([]string, 0, len(encoding))
+	for property := range encoding {
+		properties = append(properties, property)
+	}
+
+	sort.Strings(properties)
+
+	for _, property := range properties {
+		if style := encoding[property].Style; !isStrEmpty(style) && !encodingStyles[style] {
+			return fmt.Errorf("encoding %s: unsupported style %q", property, style)
+		}
+	}
+
+	return nil
+}
+
+func (ct *ContentType) hasSchema() bool {
+	return !isStrEmpty(ct.Schema) || ct.InlineSchema != nil
+}
+
+func (ct *ContentType) supportsEncoding() bool {
+	name := strings.ToLower(ct.Name)
+
+	return strings.HasPrefix(name, mediaTypeMultipartPrefix) || strings.HasPrefix(name, mediaTypeFormURLEncoded)
+}
+
+// warnUnknownMediaTypes reports well formed media types, which do not use any of the registered top level types.
+func (o *OAS) warnUnknownMediaTypes(cb ConfigBuilder) {
+	for _, lc := range o.locatedContents() {
+		for _, ct := range lc.content {
+			name := ct.Name
+			if isStrEmpty(name) {
+				name = o.defaultMediaType()
+			}
+
+			topLevel := strings.ToLower(strings.SplitN(name, "/", 2)[0]) //nolint:gomnd //type and the rest.
+			if !registeredMediaTypes[topLevel] {
+				cb.warnf("%s: media type %q is not of a registered type", lc.location, name)
+			}
+		}
+	}
+}
+
+// validateResponseRefs checks if responses referring to shared ones declare no siblings of the $ref,
+// and if the referenced component responses exist.
+func validateResponseRefs(o *OAS) error {
+	declared := make(map[string]bool)
+
+	for _, component := range o.Components {
+		for name, resp := range component.Responses {
+			declared[name] = true
+
+			if !isStrEmpty(resp.Ref) {
+				return fmt.Errorf("component response %s: component responses can not refer to other ones", name)
+			}
+		}
+	}
+
+	for i := range o.DefaultResponses {
+		if err := o.DefaultResponses[i].validateRef(declared); err != nil {
+			return fmt.Errorf("default response %s: %w", o.DefaultResponses[i].key(), err)
+		}
+	}
+
+	for _, path := range o.Paths { //nolint:gocritic //consider indexing?
+		for i := range path.Responses {
+			if err := path.Responses[i].validateRef(declared); err != nil {
+				return fmt.Errorf("%s %s: response %s: %w", path.HTTPMethod, path.Route, path.Responses[i].key(), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *Response) validateRef(declared map[string]bool) error {
+	if isStrEmpty(r.Ref) {
+		return nil
+	}
+
+	if len(r.Headers) > 0 || len(r.Content) > 0 {
+		return fmt.Errorf("headers and content can not be set next to ref %q, declare a dedicated response instead", r.Ref)
+	}
+
+	if name := strings.TrimPrefix(r.Ref, componentResponsesRefPrefix); name != r.Ref && !declared[name] {
+		return fmt.Errorf("referenced response %q is not defined", r.Ref)
+	}
+
+	return nil
+}
+
+func (r *Response) validateCode() error {
+	if isStrEmpty(r.CodeRange) {
+		return nil
+	}
+
+	if r.Code != 0 {
+		return fmt.Errorf("only one of code %d or code range %q can be set", r.Code, r.CodeRange)
+	}
+
+	if r.CodeRange != ResponseCodeDefault && !responseCodeRangeRegex.MatchString(r.CodeRange) {
+		return fmt.Errorf("invalid response code range %q", r.CodeRange)
+	}
+
+	return nil
+}
+
+func validateParameters(o *OAS) error {
+	for _, path := range o.Paths { //nolint:gocritic //consider indexing?
+		for _, param := range path.Parameters {
+			if err := param.validate(); err != nil {
+				return fmt.Errorf("%s %s parameter %q: %w", path.HTTPMethod, path.Route, param.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *Parameter) validate() error {
+	if p.In == paramInPath && !p.Required {
+		return fmt.Errorf("path parameters must be required")
+	}
+
+	if p.Schema != nil && len(p.Content) > 0 {
+		return fmt.Errorf("only one of schema or content can be set")
+	}
+
+	if len(p.Content) > 1 {
+		return fmt.Errorf("content must contain exactly one media type")
+	}
+
+	if p.AllowEmptyValue && p.In != paramInQuery {
+		return fmt.Errorf("allowEmptyValue can be set only for query parameters")
+	}
+
+	if p.AllowReserved && p.In != paramInQuery {
+		return fmt.Errorf("allowReserved can be set only for query parameters")
+	}
+
+	return nil
+}
+
+// requirePathParameters marks all path parameters as required, logging a warning for each one that was not.
+func (o *OAS) requirePathParameters(cb ConfigBuilder) {
+	for pi := range o.Paths {
+		path := &o.Paths[pi]
+
+		for i := range path.Parameters {
+			param := &path.Parameters[i]
+			if param.In != paramInPath || param.Required {
+				continue
+			}
+
+			cb.warnf("%s %s: path parameter %q marked as required", path.HTTPMethod, path.Route, param.Name)
+
+			param.Required = true
+		}
+	}
+}
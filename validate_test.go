@@ -0,0 +1,942 @@
+package docs
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestUnitValidateServers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		server  Server
+		wantErr bool
+	}{
+		{
+			name:   "plain URL",
+			server: Server{URL: "https://petstore.swagger.io/v2"},
+		},
+		{
+			name: "all variables defined",
+			server: Server{
+				URL: "https://{env}.example.com:{port}",
+				Variables: ServerVariables{
+					"env":  {Default: "prod", Enum: []string{"prod", "staging"}},
+					"port": {Default: "443"},
+				},
+				Extensions: map[string]interface{}{"x-internal": true},
+			},
+		},
+		{
+			name:    "undefined variable",
+			server:  Server{URL: "https://{env}.example.com"},
+			wantErr: true,
+		},
+		{
+			name: "default missing from enum",
+			server: Server{
+				URL:       "https://{env}.example.com",
+				Variables: ServerVariables{"env": {Default: "dev", Enum: []string{"prod", "staging"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing default",
+			server: Server{
+				URL:       "https://{env}.example.com",
+				Variables: ServerVariables{"env": {}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid extension key",
+			server: Server{
+				URL:        "https://example.com",
+				Extensions: map[string]interface{}{"internal": true},
+			},
+			wantErr: true,
+		},
+		{
+			name:   "relative URL",
+			server: Server{URL: "/api/v1"},
+		},
+		{
+			name:    "misspelled scheme",
+			server:  Server{URL: "htp://example.com"},
+			wantErr: true,
+		},
+		{
+			name:    "missing host",
+			server:  Server{URL: "https:///v1"},
+			wantErr: true,
+		},
+		{
+			name:    "empty URL",
+			server:  Server{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			o := OAS{Servers: Servers{tt.server}}
+
+			err := o.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUnitValidatePathItemRefs(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.SetPathItem("/users", PathItem{Ref: "./paths/users.yaml"})
+
+	if err := o.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	o.Paths = Paths{Path{Route: "/users", HTTPMethod: "GET"}}
+
+	if err := o.Validate(); err == nil || !strings.Contains(err.Error(), "cannot have operations") {
+		t.Errorf("expected an error for operations on a route defined by a ref, got %v", err)
+	}
+}
+
+func TestUnitValidatePathServers(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.PathItems = PathItems{"/users": PathItem{Servers: Servers{Server{URL: "htp://users.example.com"}}}}
+
+	if err := o.Validate(); err == nil {
+		t.Error("expected an error for the malformed path item server URL")
+	}
+
+	o = New()
+	o.Paths = Paths{Path{Route: "/users", HTTPMethod: "GET", Servers: Servers{Server{URL: "ftp://example.com"}}}}
+
+	if err := o.Validate(); err == nil {
+		t.Error("expected an error for the malformed operation server URL")
+	}
+}
+
+func TestUnitValidatePathParameters(t *testing.T) {
+	t.Parallel()
+
+	jsonContent := ContentTypes{ContentType{Name: "application/json", Schema: "#/components/schemas/Filter"}}
+
+	tests := []struct {
+		name    string
+		param   Parameter
+		wantErr bool
+	}{
+		{
+			name:  "schema only",
+			param: Parameter{Name: "q", In: "query", Schema: &Schema{Type: "string"}},
+		},
+		{
+			name:  "content only",
+			param: Parameter{Name: "filter", In: "query", Content: jsonContent},
+		},
+		{
+			name:    "schema and content",
+			param:   Parameter{Name: "filter", In: "query", Schema: &Schema{Type: "string"}, Content: jsonContent},
+			wantErr: true,
+		},
+		{
+			name: "multiple media types",
+			param: Parameter{Name: "filter", In: "query", Content: append(ContentTypes{
+				ContentType{Name: "application/xml"},
+			}, jsonContent...)},
+			wantErr: true,
+		},
+		{
+			name:  "empty value allowed in query",
+			param: Parameter{Name: "verbose", In: "query", AllowEmptyValue: true, AllowReserved: true},
+		},
+		{
+			name:    "empty value allowed in header",
+			param:   Parameter{Name: "X-Verbose", In: "header", AllowEmptyValue: true},
+			wantErr: true,
+		},
+		{
+			name:    "reserved allowed in header",
+			param:   Parameter{Name: "X-Redirect", In: "header", AllowReserved: true},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			o := OAS{
+				Paths:      Paths{Path{Route: "/search", HTTPMethod: "GET", Parameters: Parameters{tt.param}}},
+				Components: Components{Component{Schemas: Schemas{Schema{Name: "Filter"}}}},
+			}
+
+			err := o.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUnitValidateHTTPMethods(t *testing.T) {
+	t.Parallel()
+
+	for _, method := range []string{"GET", "put", "Post", "DELETE", "options", "HEAD", "patch", "TRACE"} {
+		o := OAS{Paths: Paths{Path{Route: "/users", HTTPMethod: method}}}
+		if err := o.Validate(); err != nil {
+			t.Errorf("unexpected error for method %s: %v", method, err)
+		}
+	}
+
+	for _, method := range []string{"GETS", "", "CONNECT"} {
+		o := OAS{Paths: Paths{Path{Route: "/users", HTTPMethod: method}}}
+		if err := o.Validate(); err == nil {
+			t.Errorf("expected an error for method %q", method)
+		}
+	}
+}
+
+func TestUnitValidateSchemaRefs(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Paths = Paths{Path{
+		Route:      "/users",
+		HTTPMethod: "GET",
+		Responses: Responses{Response{
+			Code:    200,
+			Content: ContentTypes{ContentType{Name: "application/json", Schema: "#/components/schemas/User"}},
+		}},
+	}}
+
+	if err := o.Validate(); err == nil {
+		t.Error("expected an error for undefined schema ref")
+	}
+
+	_ = o.AddComponentSchema(Schema{Name: "User"})
+
+	if err := o.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestUnitValidateProperties(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Components = Components{Component{Schemas: Schemas{Schema{
+		Name: "User", Type: "object",
+		Properties: Properties{
+			Property{Name: "address", Schema: Schema{Type: "object", Properties: Properties{
+				Property{Name: "street", Schema: Schema{Type: "string"}},
+				Property{Name: "street", Schema: Schema{Type: "integer"}},
+			}}},
+		},
+	}}}}
+
+	err := o.Validate()
+	if err == nil || !strings.Contains(err.Error(), `property "street" is declared more than once`) {
+		t.Errorf("expected an error for the duplicate property, got %v", err)
+	}
+
+	o.Components[0].Schemas[0].Properties[0].Schema.Properties[1].Name = "city"
+
+	if err := o.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestUnitValidateRefsResolve(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Components = Components{Component{Schemas: Schemas{
+		Schema{Name: "User", Type: "object", Properties: Properties{
+			Property{Name: "address", Schema: Schema{Ref: "#/components/schemas/Address"}},
+			Property{Name: "friends", Schema: Schema{Type: "array", Items: &Schema{Ref: "#/components/schemas/User"}}},
+		}},
+		Schema{Name: "Pet", OneOf: Schemas{Schema{Ref: "#/components/schemas/Cat"}}},
+		Schema{Name: "Cat"},
+	}}}
+
+	err := o.ValidateRefsResolve()
+	if err == nil || !strings.Contains(err.Error(), "component schema User") ||
+		!strings.Contains(err.Error(), "#/components/schemas/Address") {
+		t.Errorf("expected an error for the unresolved property ref, got %v", err)
+	}
+
+	if err := o.Validate(); err == nil {
+		t.Error("expected Validate to report the unresolved ref as well")
+	}
+
+	_ = o.AddComponentSchema(Schema{Name: "Address"})
+
+	if err := o.ValidateRefsResolve(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestUnitValidateDiscriminators(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Components = Components{Component{Schemas: Schemas{
+		Schema{
+			Name: "Pet",
+			OneOf: Schemas{
+				Schema{Ref: "#/components/schemas/Cat"},
+				Schema{Ref: "#/components/schemas/Dog"},
+				Schema{Ref: "#/components/schemas/Bird"},
+				Schema{Type: "object"},
+			},
+			Discriminator: &Discriminator{PropertyName: "petType"},
+		},
+		Schema{Name: "BasePet", Required: []string{"petType"}},
+		Schema{Name: "Cat", Required: []string{"petType", "name"}},
+		Schema{Name: "Dog", AllOf: Schemas{Schema{Ref: "#/components/schemas/BasePet"}}},
+		Schema{Name: "Bird", Required: []string{"name"}},
+	}}}
+
+	err := o.Validate()
+	if err == nil || !strings.HasSuffix(err.Error(), `"petType" is not required by subschemas Bird, oneOf[3]`) {
+		t.Errorf("expected an error naming the offending subschemas, got %v", err)
+	}
+
+	o.Components[0].Schemas[0].OneOf = o.Components[0].Schemas[0].OneOf[:2]
+
+	if err := o.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	o.Components[0].Schemas[0].AnyOf = Schemas{Schema{Ref: "#/components/schemas/Bird"}}
+
+	err = o.Validate()
+	if err == nil || !strings.HasSuffix(err.Error(), `"petType" is not required by subschemas Bird`) {
+		t.Errorf("expected an error naming the offending anyOf subschema, got %v", err)
+	}
+
+	o.Components[0].Schemas[0].AnyOf = nil
+	o.Paths = Paths{Path{Route: "/pets", HTTPMethod: "POST", RequestBody: RequestBody{Content: ContentTypes{
+		ContentType{Name: "application/json", InlineSchema: &Schema{
+			OneOf:         Schemas{Schema{Ref: "#/components/schemas/Cat"}, Schema{Type: "object"}},
+			Discriminator: &Discriminator{PropertyName: "petType"},
+		}},
+	}}}}
+
+	err = o.Validate()
+	if err == nil || !strings.HasPrefix(err.Error(), "POST /pets: ") || !strings.HasSuffix(err.Error(), "oneOf[1]") {
+		t.Errorf("expected an error for the inline schema, got %v", err)
+	}
+}
+
+func TestUnitValidateOperationIDs(t *testing.T) {
+	t.Parallel()
+
+	o := OAS{Paths: Paths{
+		Path{Route: "/users", HTTPMethod: "GET", OperationID: "users"},
+		Path{Route: "/users", HTTPMethod: "POST", OperationID: "users"},
+	}}
+
+	if err := o.Validate(); err == nil {
+		t.Error("expected an error for duplicate operationId")
+	}
+}
+
+func TestUnitValidateOperationIDPattern(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Paths = Paths{
+		Path{Route: "/users", HTTPMethod: "GET", OperationID: "listUsers"},
+		Path{Route: "/users", HTTPMethod: "POST", OperationID: "Create_User"},
+		Path{Route: "/users/{id}", HTTPMethod: "DELETE", OperationID: "delete-user"},
+		Path{Route: "/health", HTTPMethod: "GET"},
+	}
+	pattern := regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)
+
+	if err := o.validateOperationIDPattern(ConfigBuilder{}); err != nil {
+		t.Errorf("expected no pattern to be enforced by default, got %v", err)
+	}
+
+	err := o.validateOperationIDPattern(ConfigBuilder{OperationIDPattern: pattern})
+	if err == nil || !strings.HasSuffix(err.Error(), `"Create_User" (POST /users), "delete-user" (DELETE /users/{id})`) {
+		t.Errorf("expected an error reporting all violations, got %v", err)
+	}
+
+	cb := ConfigBuilder{OperationIDPattern: pattern, OperationIDCase: OperationIDCamel}
+	if err := o.validateOperationIDPattern(cb); err != nil {
+		t.Errorf("expected the pattern to be checked after applying the case, got %v", err)
+	}
+
+	if _, err := o.BuildDocsBytes(ConfigBuilder{OperationIDPattern: pattern}); !errors.Is(err, ErrValidation) {
+		t.Errorf("expected ErrValidation from the build, got %v", err)
+	}
+}
+
+func TestUnitValidateExamples(t *testing.T) {
+	t.Parallel()
+
+	newOAS := func(example Example) OAS {
+		return OAS{Paths: Paths{Path{
+			Route:      "/users",
+			HTTPMethod: "GET",
+			Responses: Responses{Response{Code: 200, Content: ContentTypes{ContentType{
+				Name:     "application/json",
+				Examples: Examples{"large": example},
+			}}}},
+		}}}
+	}
+
+	valid := []Example{
+		{Value: map[string]string{"name": "John"}},
+		{ExternalValue: "https://example.com/samples/users.json"},
+	}
+	for _, example := range valid {
+		o := newOAS(example)
+		if err := o.Validate(); err != nil {
+			t.Errorf("unexpected error for %+v: %v", example, err)
+		}
+	}
+
+	o := newOAS(Example{Value: "inline", ExternalValue: "https://example.com/samples/users.json"})
+	if err := o.Validate(); err == nil {
+		t.Error("expected an error for example with both value and externalValue")
+	}
+}
+
+func TestUnitValidatePathParamsRequired(t *testing.T) {
+	t.Parallel()
+
+	newOAS := func() OAS {
+		return OAS{Paths: Paths{Path{
+			Route:      "/users/{id}",
+			HTTPMethod: "GET",
+			Parameters: Parameters{Parameter{Name: "id", In: paramInPath}},
+		}}}
+	}
+
+	o := newOAS()
+	if err := o.Validate(); err == nil {
+		t.Error("expected an error for path parameter which is not required")
+	}
+
+	o = newOAS()
+
+	build, err := o.prepareForBuild(ConfigBuilder{AutoRequirePathParams: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !build.Paths[0].Parameters[0].Required {
+		t.Error("expected path parameter to be marked as required")
+	}
+
+	if o.Paths[0].Parameters[0].Required {
+		t.Error("expected the path parameter of the OAS itself to be left untouched")
+	}
+
+	if _, err := o.BuildDocsBytes(ConfigBuilder{AutoRequirePathParams: true}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if o.Paths[0].Parameters[0].Required {
+		t.Error("expected building not to modify the OAS")
+	}
+}
+
+func TestUnitValidateResponseCodes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		resp    Response
+		wantErr bool
+	}{
+		{resp: Response{Code: 200}},
+		{resp: Response{CodeRange: "2XX"}},
+		{resp: Response{CodeRange: ResponseCodeDefault}},
+		{resp: Response{CodeRange: "2xx"}, wantErr: true},
+		{resp: Response{CodeRange: "6XX"}, wantErr: true},
+		{resp: Response{Code: 200, CodeRange: "2XX"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		o := OAS{Paths: Paths{Path{Route: "/users", HTTPMethod: "GET", Responses: Responses{tt.resp}}}}
+
+		err := o.Validate()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Validate() for %+v error = %v, wantErr %v", tt.resp, err, tt.wantErr)
+		}
+	}
+}
+
+func TestUnitValidateDuplicateResponseCodes(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Paths = Paths{Path{Route: "/users", HTTPMethod: "GET", Responses: Responses{
+		Response{Code: 200, Description: "OK"},
+		Response{Code: 404, Description: "Not found"},
+		Response{Code: 200, Description: "Also OK"},
+	}}}
+
+	err := o.Validate()
+	if err == nil || err.Error() != "GET /users: response 200 is defined more than once" {
+		t.Errorf("expected an error naming the route and code, got %v", err)
+	}
+
+	o.Paths[0].Responses = o.Paths[0].Responses[:2]
+	o.DefaultResponses = Responses{
+		Response{CodeRange: ResponseCodeDefault, Description: "Error"},
+		Response{CodeRange: ResponseCodeDefault, Description: "Other error"},
+	}
+
+	if err := o.Validate(); err == nil || !strings.Contains(err.Error(), "default responses") {
+		t.Errorf("expected an error for the duplicate default response, got %v", err)
+	}
+}
+
+func TestUnitValidateMediaTypes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		mediaType string
+		wantErr   bool
+	}{
+		{name: "json", mediaType: "application/json"},
+		{name: "vendor specific", mediaType: "application/vnd.api+json"},
+		{name: "with parameters", mediaType: "text/plain; charset=utf-8"},
+		{name: "subtype wildcard", mediaType: "image/*"},
+		{name: "full wildcard", mediaType: "*/*"},
+		{name: "missing subtype", mediaType: "application", wantErr: true},
+		{name: "empty subtype", mediaType: "application/", wantErr: true},
+		{name: "type wildcard only", mediaType: "*/json", wantErr: true},
+		{name: "whitespace", mediaType: "application /json", wantErr: true},
+		{name: "empty", mediaType: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			o := New()
+			o.Paths = Paths{Path{
+				Route:      "/files",
+				HTTPMethod: "GET",
+				Responses:  Responses{Response{Code: 200, Content: ContentTypes{ContentType{Name: tt.mediaType}}}},
+			}}
+
+			err := o.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUnitWarnUnknownMediaTypes(t *testing.T) {
+	t.Parallel()
+
+	var msgs []string
+
+	o := New()
+	o.Paths = Paths{Path{
+		Route:       "/files",
+		HTTPMethod:  "POST",
+		RequestBody: RequestBody{Content: ContentTypes{ContentType{Name: "aplication/json"}}},
+		Responses:   Responses{Response{Code: 200, Content: ContentTypes{ContentType{Name: "application/json"}}}},
+	}}
+
+	o.warnUnknownMediaTypes(ConfigBuilder{Logger: func(msg string) { msgs = append(msgs, msg) }})
+
+	if len(msgs) != 1 || !strings.Contains(msgs[0], "aplication/json") {
+		t.Errorf("expected a single warning for the unknown media type, got %v", msgs)
+	}
+
+	msgs = nil
+	o.DefaultResponses = Responses{Response{Code: 500, Content: ContentTypes{ContentType{Name: "problem/json"}}}}
+
+	o.warnUnknownMediaTypes(ConfigBuilder{Logger: func(msg string) { msgs = append(msgs, msg) }})
+
+	if len(msgs) != 2 || !strings.Contains(msgs[1], "default response 500: media type \"problem/json\"") {
+		t.Errorf("expected a warning for the unknown media type of the default response, got %v", msgs)
+	}
+
+	msgs = nil
+	o.Components = Components{Component{
+		Responses: map[string]Response{"Error": {Content: ContentTypes{ContentType{Name: "error/json"}}}},
+		PathItems: ComponentPathItems{"Webhook": ComponentPathItem{Operations: Paths{Path{
+			HTTPMethod:  "POST",
+			RequestBody: RequestBody{Content: ContentTypes{ContentType{Name: "event/json"}}},
+		}}}},
+	}}
+
+	o.warnUnknownMediaTypes(ConfigBuilder{Logger: func(msg string) { msgs = append(msgs, msg) }})
+
+	if len(msgs) != 4 || !strings.Contains(msgs[2], "component response Error") ||
+		!strings.Contains(msgs[3], "component path item Webhook") {
+		t.Errorf("expected warnings for the unknown media types of the components, got %v", msgs)
+	}
+
+	o.Components[0].PathItems["Webhook"].Operations[0].RequestBody.Content[0].Name = "application json"
+
+	if err := validateMediaTypes(&o); err == nil || !strings.Contains(err.Error(), "component path item Webhook") {
+		t.Errorf("expected an error for the malformed media type of the component path item, got %v", err)
+	}
+
+	o.Components[0].Responses["Error"] = Response{Content: ContentTypes{ContentType{Name: "text"}}}
+
+	if err := validateMediaTypes(&o); err == nil || !strings.Contains(err.Error(), "component response Error") {
+		t.Errorf("expected an error for the malformed media type of the component response, got %v", err)
+	}
+}
+
+func TestUnitValidateInfoExtensions(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Info.Extensions = map[string]interface{}{"x-logo": map[string]string{"url": "https://example.com/logo.png"}}
+
+	if err := o.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	o.Info.Extensions["logo"] = "https://example.com/logo.png"
+
+	if err := o.Validate(); err == nil {
+		t.Error("expected an error for the extension key without x- prefix")
+	}
+}
+
+func TestUnitValidateInfoLicense(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Info.License = License{Name: "Apache 2.0", Identifier: "Apache-2.0"}
+
+	if err := o.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	o.Info.License.URL = "https://www.apache.org/licenses/LICENSE-2.0.html"
+
+	if err := o.Validate(); err == nil || !strings.Contains(err.Error(), "only one of url or identifier") {
+		t.Errorf("expected an error for both license url and identifier, got %v", err)
+	}
+}
+
+func TestUnitValidateEncoding(t *testing.T) {
+	t.Parallel()
+
+	encoding := map[string]Encoding{"file": {ContentType: "application/pdf"}}
+
+	for name, wantErr := range map[string]bool{
+		"multipart/form-data":               false,
+		"application/x-www-form-urlencoded": false,
+		"application/json":                  true,
+	} {
+		o := New()
+		o.Paths = Paths{Path{
+			Route:       "/resumes",
+			HTTPMethod:  "POST",
+			RequestBody: RequestBody{Content: ContentTypes{ContentType{Name: name, Encoding: encoding}}},
+		}}
+
+		if err := o.Validate(); (err != nil) != wantErr {
+			t.Errorf("%s: Validate() error = %v, wantErr %v", name, err, wantErr)
+		}
+	}
+}
+
+func TestUnitValidateEncodingStyles(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Paths = Paths{Path{
+		Route:      "/search",
+		HTTPMethod: "POST",
+		RequestBody: RequestBody{Content: ContentTypes{ContentType{
+			Name:     "application/x-www-form-urlencoded",
+			Encoding: map[string]Encoding{"tags": {Style: "form"}, "filter": {Style: "deepObject"}},
+		}}},
+	}}
+
+	if err := o.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	o.Paths[0].RequestBody.Content[0].Encoding["tags"] = Encoding{Style: "simple"}
+
+	if err := o.Validate(); err == nil || !strings.Contains(err.Error(), `encoding tags: unsupported style "simple"`) {
+		t.Errorf("expected an error for the unsupported style, got %v", err)
+	}
+}
+
+func TestUnitValidateSecurityNames(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	_ = o.AddSecurityScheme(SecurityScheme{
+		Name: "petstore_auth",
+		Type: "oauth2",
+		Flows: SecurityFlows{SecurityFlow{
+			Type:   "implicit",
+			Scopes: SecurityScopes{SecurityScope{Name: "read:pets"}, SecurityScope{Name: "write:pets"}},
+		}},
+	})
+	o.Paths = Paths{Path{
+		Route:      "/pets",
+		HTTPMethod: "GET",
+		Security:   SecurityEntities{UseSecurity("petstore_auth", "read:pets")},
+	}}
+
+	if err := o.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	o.Paths[0].Security = append(o.Paths[0].Security, UseSecurity("petstore_oauth"))
+
+	if err := o.Validate(); err == nil || !strings.Contains(err.Error(), "petstore_oauth") {
+		t.Errorf("expected an error for the undeclared security scheme, got %v", err)
+	}
+}
+
+func TestUnitValidateSecurityScopes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		scheme  SecurityScheme
+		scopes  []string
+		ver     OASVersion
+		wantErr bool
+	}{
+		{
+			name: "declared oauth2 scopes",
+			scheme: SecurityScheme{Type: "oauth2", Flows: SecurityFlows{
+				SecurityFlow{Type: "implicit", Scopes: SecurityScopes{SecurityScope{Name: "read:users"}}},
+				SecurityFlow{Type: "password", Scopes: SecurityScopes{SecurityScope{Name: "write:users"}}},
+			}},
+			scopes: []string{"read:users", "write:users"},
+		},
+		{
+			name: "undeclared oauth2 scope",
+			scheme: SecurityScheme{Type: "oauth2", Flows: SecurityFlows{
+				SecurityFlow{Type: "implicit", Scopes: SecurityScopes{SecurityScope{Name: "read:users"}}},
+			}},
+			scopes:  []string{"admin"},
+			wantErr: true,
+		},
+		{
+			name:   "openIdConnect scopes",
+			scheme: SecurityScheme{Type: "openIdConnect"},
+			scopes: []string{"profile"},
+		},
+		{
+			name:    "api key scopes for 3.0",
+			scheme:  SecurityScheme{Type: "apiKey"},
+			scopes:  []string{"admin"},
+			ver:     "3.0.3",
+			wantErr: true,
+		},
+		{
+			name:   "api key roles for 3.1",
+			scheme: SecurityScheme{Type: "apiKey"},
+			scopes: []string{"admin"},
+			ver:    "3.1.0",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.scheme.validateRequestedScopes(tt.scopes, tt.ver)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateRequestedScopes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUnitValidateComponentPathItems(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.Components = Components{Component{PathItems: ComponentPathItems{"Notification": ComponentPathItem{
+		Operations: Paths{Path{
+			HTTPMethod: "POST",
+			RequestBody: RequestBody{Content: ContentTypes{
+				ContentType{Name: "application/json", Schema: "#/components/schemas/Event"},
+			}},
+		}},
+	}}}}
+
+	if err := o.Validate(); err == nil || !strings.Contains(err.Error(), "Event") {
+		t.Errorf("expected an error for the undefined schema, got %v", err)
+	}
+
+	_ = o.AddComponentSchema(Schema{Name: "Event"})
+
+	if err := o.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	o.Components[0].PathItems["Notification"].Operations[0].HTTPMethod = "SEND"
+
+	if err := o.Validate(); err == nil {
+		t.Error("expected an error for the invalid HTTP method")
+	}
+}
+
+func TestUnitValidateResponseRefs(t *testing.T) {
+	t.Parallel()
+
+	shared := Components{Component{Responses: map[string]Response{
+		"NotFound": {Description: "Not Found", Content: ContentTypes{ContentType{Name: "application/json"}}},
+	}}}
+
+	tests := []struct {
+		name     string
+		response Response
+		wantErr  bool
+	}{
+		{
+			name:     "plain ref",
+			response: Response{Code: 404, Ref: ComponentResponseRef("NotFound")},
+		},
+		{
+			name:     "ref with description",
+			response: Response{Code: 404, Ref: ComponentResponseRef("NotFound"), Description: "No such user"},
+		},
+		{
+			name: "ref with headers",
+			response: Response{
+				Code:    201,
+				Ref:     ComponentResponseRef("NotFound"),
+				Headers: Headers{"Location": Header{Schema: &Schema{Type: "string"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ref with content",
+			response: Response{
+				Code:    404,
+				Ref:     ComponentResponseRef("NotFound"),
+				Content: ContentTypes{ContentType{Name: "text/plain"}},
+			},
+			wantErr: true,
+		},
+		{
+			name:     "undefined ref",
+			response: Response{Code: 409, Ref: ComponentResponseRef("Conflict")},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			o := OAS{
+				Paths:      Paths{Path{Route: "/users", HTTPMethod: "POST", Responses: Responses{tt.response}}},
+				Components: shared,
+			}
+
+			err := o.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUnitValidateSecuritySchemes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		scheme  SecurityScheme
+		wantErr bool
+	}{
+		{
+			name: "openIdConnect with URL",
+			scheme: SecurityScheme{
+				Name:             "sso",
+				Type:             "openIdConnect",
+				OpenIDConnectURL: "https://sso.example.com/.well-known/openid-configuration",
+			},
+		},
+		{
+			name:    "openIdConnect without URL",
+			scheme:  SecurityScheme{Name: "sso", Type: "openIdConnect"},
+			wantErr: true,
+		},
+		{
+			name:    "openIdConnect with relative URL",
+			scheme:  SecurityScheme{Name: "sso", Type: "openIdConnect", OpenIDConnectURL: "/openid-configuration"},
+			wantErr: true,
+		},
+		{
+			name:   "apiKey without URL",
+			scheme: SecurityScheme{Name: "api_key", Type: "apiKey", In: "header"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			o := OAS{Components: Components{Component{SecuritySchemes: SecuritySchemes{tt.scheme}}}}
+
+			err := o.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest //replaces the standard logger output.
+func TestUnitWarningsSilentWithoutLogger(t *testing.T) {
+	var logged bytes.Buffer
+
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+
+	o := New()
+	o.Paths = Paths{Path{
+		Route:       "/files",
+		HTTPMethod:  "POST",
+		RequestBody: RequestBody{Content: ContentTypes{ContentType{Name: "aplication/json"}}},
+	}}
+
+	o.warnUnknownMediaTypes(ConfigBuilder{})
+
+	if logged.Len() > 0 {
+		t.Errorf("expected no output without a Logger, got %q", logged.String())
+	}
+}
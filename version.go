@@ -0,0 +1,272 @@
+package docs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SetVersion sets the OAS version, transforming the version specific constructs in place.
+//
+// Upgrading to OAS 3.1 moves the example of every schema into its examples, as the single example is deprecated.
+// Downgrading to OAS 3.0 moves the only example of a schema back, failing for schemas with several examples
+// and for constructs of OAS 3.1 which cannot be represented, e.g. const, patternProperties or component path items.
+// Nullable schemas are emitted as nullable for OAS 3.0 and as type arrays with null for OAS 3.1 on their own,
+// so upgrading fails only for nullable schemas without a type. The OAS is left untouched on failure.
+func (o *OAS) SetVersion(v OASVersion) error {
+	switch {
+	case v.is31():
+		if err := o.checkNullableTypes(); err != nil {
+			return fmt.Errorf("failed setting OAS version %s: %w", v, err)
+		}
+
+		_ = o.walkSchemas(func(s *Schema) error {
+			if s.Example != nil {
+				s.Examples = append([]interface{}{s.Example}, s.Examples...)
+				s.Example = nil
+			}
+
+			return nil
+		})
+	case strings.HasPrefix(string(v), oasVersion30Prefix):
+		if err := o.checkDowngradeTo30(); err != nil {
+			return fmt.Errorf("failed setting OAS version %s: %w", v, err)
+		}
+
+		_ = o.walkSchemas(func(s *Schema) error {
+			if len(s.Examples) == 1 {
+				s.Example, s.Examples = s.Examples[0], nil
+			}
+
+			return nil
+		})
+	default:
+		return fmt.Errorf("unsupported OAS version %q", v)
+	}
+
+	o.OASVersion = v
+	o.Invalidate()
+
+	return nil
+}
+
+// checkDowngradeTo30 returns an error for the first construct of OAS 3.1 which cannot be represented in OAS 3.0.
+func (o *OAS) checkDowngradeTo30() error {
+	if !isStrEmpty(o.Info.Summary) {
+		return fmt.Errorf("info summary is not supported by OAS 3.0")
+	}
+
+	if !isStrEmpty(o.Info.License.Identifier) {
+		return fmt.Errorf("license identifier is not supported by OAS 3.0")
+	}
+
+	if !isStrEmpty(o.JSONSchemaDialect) {
+		return fmt.Errorf("JSON Schema dialect is not supported by OAS 3.0")
+	}
+
+	if err := o.checkResponseRefDescriptions(); err != nil {
+		return err
+	}
+
+	for _, component := range o.Components {
+		if len(component.PathItems) > 0 {
+			return fmt.Errorf("component path items are not supported by OAS 3.0")
+		}
+	}
+
+	return o.walkSchemas(func(s *Schema) error {
+		switch {
+		case len(s.Examples) > 1:
+			return fmt.Errorf("%d examples cannot be represented by the single example of OAS 3.0", len(s.Examples))
+		case len(s.Examples) == 1 && s.Example != nil:
+			return fmt.Errorf("both example and examples are set")
+		case s.Const != nil:
+			return fmt.Errorf("const is not supported by OAS 3.0")
+		case len(s.PatternProperties) > 0:
+			return fmt.Errorf("patternProperties are not supported by OAS 3.0")
+		case !isStrEmpty(s.ContentEncoding) || !isStrEmpty(s.ContentMediaType):
+			return fmt.Errorf("contentEncoding and contentMediaType are not supported by OAS 3.0")
+		}
+
+		return nil
+	})
+}
+
+// checkResponseRefDescriptions returns an error for the first response overriding the description of the ref,
+// as OAS 3.0 ignores siblings of refs.
+func (o *OAS) checkResponseRefDescriptions() error {
+	check := func(responses Responses) error {
+		for i := range responses {
+			if !isStrEmpty(responses[i].Ref) && !isStrEmpty(responses[i].Description) {
+				return fmt.Errorf("response %s: description next to the ref is not supported by OAS 3.0",
+					responses[i].key())
+			}
+		}
+
+		return nil
+	}
+
+	if err := check(o.DefaultResponses); err != nil {
+		return fmt.Errorf("default responses: %w", err)
+	}
+
+	for i := range o.Paths {
+		if err := check(o.Paths[i].Responses); err != nil {
+			return fmt.Errorf("%s %s: %w", o.Paths[i].HTTPMethod, o.Paths[i].Route, err)
+		}
+	}
+
+	return nil
+}
+
+// checkNullableTypes returns an error for the first nullable schema without a type,
+// as OAS 3.1 emits nullable schemas as type arrays with null.
+func (o *OAS) checkNullableTypes() error {
+	return o.walkSchemas(func(s *Schema) error {
+		if s.Nullable && isStrEmpty(s.Type) {
+			return fmt.Errorf("nullable schemas require a type for OAS 3.1")
+		}
+
+		return nil
+	})
+}
+
+// walkSchemas calls the function for every schema of the OAS and all their subschemas,
+// stopping at the first error, which is returned along with the location of the schema.
+func (o *OAS) walkSchemas(fn func(s *Schema) error) error {
+	for i := range o.Paths {
+		if err := walkPathSchemas(&o.Paths[i], fn); err != nil {
+			return fmt.Errorf("%s %s: %w", o.Paths[i].HTTPMethod, o.Paths[i].Route, err)
+		}
+	}
+
+	for i := range o.DefaultResponses {
+		if err := walkResponseSchemas(&o.DefaultResponses[i], fn); err != nil {
+			return fmt.Errorf("default response %s: %w", o.DefaultResponses[i].key(), err)
+		}
+	}
+
+	for _, component := range o.Components {
+		for i := range component.Schemas {
+			if err := walkSchema(&component.Schemas[i], fn); err != nil {
+				return fmt.Errorf("component schema %s: %w", component.Schemas[i].Name, err)
+			}
+		}
+
+		for name, resp := range component.Responses {
+			if err := walkResponseSchemas(&resp, fn); err != nil {
+				return fmt.Errorf("component response %s: %w", name, err)
+			}
+		}
+
+		for _, name := range component.PathItems.names() {
+			item := component.PathItems[name]
+			for i := range item.Operations {
+				if err := walkPathSchemas(&item.Operations[i], fn); err != nil {
+					return fmt.Errorf("component path item %s: %w", name, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func walkPathSchemas(p *Path, fn func(s *Schema) error) error {
+	for i := range p.Parameters {
+		if p.Parameters[i].Schema != nil {
+			if err := walkSchema(p.Parameters[i].Schema, fn); err != nil {
+				return fmt.Errorf("parameter %s: %w", p.Parameters[i].Name, err)
+			}
+		}
+
+		if err := walkContentSchemas(p.Parameters[i].Content, fn); err != nil {
+			return fmt.Errorf("parameter %s: %w", p.Parameters[i].Name, err)
+		}
+	}
+
+	if err := walkContentSchemas(p.RequestBody.Content, fn); err != nil {
+		return fmt.Errorf("request body: %w", err)
+	}
+
+	for i := range p.Responses {
+		if err := walkResponseSchemas(&p.Responses[i], fn); err != nil {
+			return fmt.Errorf("response %s: %w", p.Responses[i].key(), err)
+		}
+	}
+
+	return nil
+}
+
+func walkResponseSchemas(r *Response, fn func(s *Schema) error) error {
+	if err := walkContentSchemas(r.Content, fn); err != nil {
+		return err
+	}
+
+	return walkHeaderSchemas(r.Headers, fn)
+}
+
+func walkHeaderSchemas(headers Headers, fn func(s *Schema) error) error {
+	for name, header := range headers {
+		if header.Schema != nil {
+			if err := walkSchema(header.Schema, fn); err != nil {
+				return fmt.Errorf("header %s: %w", name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func walkContentSchemas(cts ContentTypes, fn func(s *Schema) error) error {
+	for i := range cts {
+		if cts[i].InlineSchema != nil {
+			if err := walkSchema(cts[i].InlineSchema, fn); err != nil {
+				return fmt.Errorf("%s: %w", cts[i].Name, err)
+			}
+		}
+
+		for property, enc := range cts[i].Encoding {
+			if err := walkHeaderSchemas(enc.Headers, fn); err != nil {
+				return fmt.Errorf("%s: encoding %s: %w", cts[i].Name, property, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func walkSchema(s *Schema, fn func(s *Schema) error) error {
+	if err := fn(s); err != nil {
+		return err
+	}
+
+	for i := range s.Properties {
+		if err := walkSchema(&s.Properties[i].Schema, fn); err != nil {
+			return fmt.Errorf("property %s: %w", s.Properties[i].Name, err)
+		}
+	}
+
+	if s.Items != nil {
+		if err := walkSchema(s.Items, fn); err != nil {
+			return fmt.Errorf("items: %w", err)
+		}
+	}
+
+	for _, subSchemas := range []Schemas{s.OneOf, s.AnyOf, s.AllOf} {
+		for i := range subSchemas {
+			if err := walkSchema(&subSchemas[i], fn); err != nil {
+				return err
+			}
+		}
+	}
+
+	for pattern, ps := range s.PatternProperties {
+		if ps != nil {
+			if err := walkSchema(ps, fn); err != nil {
+				return fmt.Errorf("pattern property %s: %w", pattern, err)
+			}
+		}
+	}
+
+	return nil
+}
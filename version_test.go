@@ -0,0 +1,130 @@
+package docs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnitSetVersionUpgrade(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.OASVersion = "3.0.3"
+	o.Components = Components{Component{Schemas: Schemas{Schema{
+		Name: "User", Type: "object", Example: "first",
+		Properties: Properties{Property{Name: "id", Schema: Schema{Type: "integer", Example: 1, Examples: []interface{}{2}}}},
+	}}}}
+	o.Paths = Paths{Path{
+		Route: "/users", HTTPMethod: "GET",
+		Responses: Responses{Response{Code: 200, Headers: Headers{
+			"X-Rate-Limit": Header{Schema: &Schema{Type: "integer", Example: 100}},
+		}}},
+	}}
+
+	if err := o.SetVersion("3.1.0"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if o.OASVersion != "3.1.0" {
+		t.Errorf("got version %q, but want 3.1.0", o.OASVersion)
+	}
+
+	user := o.Components[0].Schemas[0]
+	if user.Example != nil || !reflect.DeepEqual(user.Examples, []interface{}{"first"}) {
+		t.Errorf("got example %v and examples %v, but want only examples [first]", user.Example, user.Examples)
+	}
+
+	if id := user.Properties[0].Schema; id.Example != nil || !reflect.DeepEqual(id.Examples, []interface{}{1, 2}) {
+		t.Errorf("got example %v and examples %v, but want only examples [1 2]", id.Example, id.Examples)
+	}
+
+	header := o.Paths[0].Responses[0].Headers["X-Rate-Limit"].Schema
+	if header.Example != nil || !reflect.DeepEqual(header.Examples, []interface{}{100}) {
+		t.Errorf("got example %v and examples %v, but want only examples [100]", header.Example, header.Examples)
+	}
+}
+
+func TestUnitSetVersionDowngrade(t *testing.T) {
+	t.Parallel()
+
+	o := New()
+	o.OASVersion = "3.1.0"
+	o.Paths = Paths{Path{
+		Route: "/users", HTTPMethod: "POST",
+		RequestBody: RequestBody{Content: ContentTypes{ContentType{
+			Name: "application/json", InlineSchema: &Schema{Type: "string", Examples: []interface{}{"jane"}},
+		}}},
+	}}
+
+	if err := o.SetVersion("3.0.3"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	schema := o.Paths[0].RequestBody.Content[0].InlineSchema
+	if schema.Example != "jane" || schema.Examples != nil {
+		t.Errorf("got example %v and examples %v, but want only example jane", schema.Example, schema.Examples)
+	}
+}
+
+func TestUnitSetVersionErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		version OASVersion
+		setup   func(o *OAS)
+	}{
+		{name: "unsupported version", version: "2.0", setup: func(o *OAS) {}},
+		{name: "several examples", version: "3.0.3", setup: func(o *OAS) {
+			o.Components = Components{Component{Schemas: Schemas{Schema{Name: "A", Examples: []interface{}{1, 2}}}}}
+		}},
+		{name: "const", version: "3.0.3", setup: func(o *OAS) {
+			o.Components = Components{Component{Schemas: Schemas{Schema{
+				Name: "A", Properties: Properties{Property{Name: "kind", Schema: Schema{Const: "a"}}},
+			}}}}
+		}},
+		{name: "pattern properties", version: "3.0.3", setup: func(o *OAS) {
+			o.Components = Components{Component{Schemas: Schemas{Schema{
+				Name: "A", PatternProperties: map[string]*Schema{"^x-": {Type: "string"}},
+			}}}}
+		}},
+		{name: "license identifier", version: "3.0.3", setup: func(o *OAS) {
+			o.Info.License.Identifier = "MIT"
+		}},
+		{name: "component path items", version: "3.0.3", setup: func(o *OAS) {
+			o.Components = Components{Component{PathItems: ComponentPathItems{"ping": ComponentPathItem{}}}}
+		}},
+		{name: "json schema dialect", version: "3.0.3", setup: func(o *OAS) {
+			o.JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+		}},
+		{name: "response ref description", version: "3.0.3", setup: func(o *OAS) {
+			o.DefaultResponses = Responses{Response{
+				Code: 404, Ref: ComponentResponseRef("NotFound"), Description: "Missing pet",
+			}}
+		}},
+		{name: "nullable without type", version: "3.1.0", setup: func(o *OAS) {
+			o.OASVersion = "3.0.3"
+			o.Components = Components{Component{Schemas: Schemas{Schema{Name: "A", Nullable: true}}}}
+		}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			o := New()
+			o.OASVersion = "3.1.0"
+			tt.setup(&o)
+			want := o.OASVersion
+
+			if err := o.SetVersion(tt.version); err == nil {
+				t.Fatal("expected an error, got nil")
+			}
+
+			if o.OASVersion != want {
+				t.Errorf("expected the version to be kept on failure, got %q", o.OASVersion)
+			}
+		})
+	}
+}